@@ -0,0 +1,144 @@
+package configuration
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/configuration"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+var propertySetSchema = map[string]*schema.Schema{
+	"visible": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Defines if the list visible and assignable to the repository or artifact.",
+	},
+	"property": {
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "A list of properties that are part of the property set.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the property.",
+				},
+				"closed_predefined_values": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether only the predefined values can be assigned to the property.",
+				},
+				"multiple_choice": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether or not user can select multiple values.",
+				},
+				"predefined_value": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Description: "Predefined values of the property.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "Predefined property name.",
+							},
+							"default_value": {
+								Type:        schema.TypeBool,
+								Computed:    true,
+								Description: "Whether the value is selected by default in the UI.",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func packPropertySet(propertySet configuration.PropertySet) map[string]interface{} {
+	properties := []interface{}{}
+	for _, property := range propertySet.Properties {
+		predefinedValues := []interface{}{}
+		for _, predefinedValue := range property.PredefinedValues {
+			predefinedValues = append(predefinedValues, map[string]interface{}{
+				"name":          predefinedValue.Name,
+				"default_value": predefinedValue.DefaultValue,
+			})
+		}
+
+		properties = append(properties, map[string]interface{}{
+			"name":                     property.Name,
+			"closed_predefined_values": property.ClosedPredefinedValue,
+			"multiple_choice":          property.MultipleChoice,
+			"predefined_value":         predefinedValues,
+		})
+	}
+
+	return map[string]interface{}{
+		"name":     propertySet.Name,
+		"visible":  propertySet.Visible,
+		"property": properties,
+	}
+}
+
+func getPropertySets(m interface{}) ([]configuration.PropertySet, error) {
+	propertySets := configuration.PropertySets{}
+	_, err := m.(util.ProvderMetadata).Client.R().SetResult(&propertySets).Get(ConfigurationEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return propertySets.PropertySets, nil
+}
+
+func DataSourceArtifactoryPropertySet() *schema.Resource {
+	var dataSourcePropertySetRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		name := d.Get("name").(string)
+
+		propertySets, err := getPropertySets(m)
+		if err != nil {
+			return diag.Errorf("failed to retrieve data from API: /%s during Read: %s", ConfigurationEndpoint, err)
+		}
+
+		matchedPropertySet := configuration.FindConfigurationById[configuration.PropertySet](propertySets, name)
+		if matchedPropertySet == nil {
+			return diag.Errorf("property set '%s' not found", name)
+		}
+
+		d.SetId(matchedPropertySet.Name)
+
+		setValue := util.MkLens(d)
+		var errors []error
+		for key, value := range packPropertySet(*matchedPropertySet) {
+			errors = setValue(key, value)
+		}
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack property set %q", errors)
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourcePropertySetRead,
+		Schema: util.MergeMaps(
+			map[string]*schema.Schema{
+				"name": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+					Description:      "Property set name.",
+				},
+			},
+			propertySetSchema,
+		),
+		Description: "Provides the property set data source. Contains the definition of a specific property set.",
+	}
+}
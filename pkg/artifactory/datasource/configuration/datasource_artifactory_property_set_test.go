@@ -0,0 +1,69 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func TestAccDataSourcePropertySet_full(t *testing.T) {
+	_, tempFqrn, name := test.MkNames("property-set-", "artifactory_property_set")
+	propertySetFqrn := "data." + tempFqrn
+	propertySetsFqrn := "data.artifactory_property_sets.all"
+
+	config := util.ExecuteTemplate("propertySet", `
+		resource "artifactory_property_set" "{{ .name }}" {
+			name    = "{{ .name }}"
+			visible = true
+
+			property {
+				name = "set1property1"
+
+				predefined_value {
+					name          = "passed-QA"
+					default_value = true
+				}
+
+				closed_predefined_values = true
+				multiple_choice          = true
+			}
+		}
+
+		data "artifactory_property_set" "{{ .name }}" {
+			name = artifactory_property_set.{{ .name }}.name
+		}
+
+		data "artifactory_property_sets" "all" {
+			depends_on = [artifactory_property_set.{{ .name }}]
+		}
+	`, map[string]interface{}{
+		"name": name,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(propertySetFqrn, "name", name),
+					resource.TestCheckResourceAttr(propertySetFqrn, "visible", "true"),
+					resource.TestCheckResourceAttr(propertySetFqrn, "property.#", "1"),
+					resource.TestCheckResourceAttr(propertySetFqrn, "property.0.name", "set1property1"),
+					resource.TestCheckResourceAttr(propertySetFqrn, "property.0.closed_predefined_values", "true"),
+					resource.TestCheckResourceAttr(propertySetFqrn, "property.0.multiple_choice", "true"),
+					resource.TestCheckResourceAttr(propertySetFqrn, "property.0.predefined_value.0.name", "passed-QA"),
+					resource.TestCheckResourceAttr(propertySetFqrn, "property.0.predefined_value.0.default_value", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs(propertySetsFqrn, "property_sets.*", map[string]string{
+						"name":    name,
+						"visible": "true",
+					}),
+				),
+			},
+		},
+	})
+}
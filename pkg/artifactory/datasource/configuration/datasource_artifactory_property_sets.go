@@ -0,0 +1,58 @@
+package configuration
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func DataSourceArtifactoryPropertySets() *schema.Resource {
+	var dataSourcePropertySetsRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		propertySets, err := getPropertySets(m)
+		if err != nil {
+			return diag.Errorf("failed to retrieve data from API: /%s during Read: %s", ConfigurationEndpoint, err)
+		}
+
+		packedPropertySets := []interface{}{}
+		for _, propertySet := range propertySets {
+			packedPropertySets = append(packedPropertySets, packPropertySet(propertySet))
+		}
+
+		d.SetId(ConfigurationEndpoint)
+
+		if err := d.Set("property_sets", packedPropertySets); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	propertySetResourceSchema := util.MergeMaps(
+		map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Property set name.",
+			},
+		},
+		propertySetSchema,
+	)
+
+	return &schema.Resource{
+		ReadContext: dataSourcePropertySetsRead,
+		Schema: map[string]*schema.Schema{
+			"property_sets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All property sets defined in Artifactory.",
+				Elem: &schema.Resource{
+					Schema: propertySetResourceSchema,
+				},
+			},
+		},
+		Description: "Provides the property sets data source. Contains the definitions of all property sets.",
+	}
+}
@@ -0,0 +1,115 @@
+package configuration
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/configuration"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/repository"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const ConfigurationEndpoint = "artifactory/api/system/configuration"
+
+var layoutSchema = map[string]*schema.Schema{
+	"artifact_path_pattern": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Please refer to: [Path Patterns](https://www.jfrog.com/confluence/display/JFROG/Repository+Layouts#RepositoryLayouts-ModulesandPathPatternsusedbyRepositoryLayouts) in the Artifactory Wiki documentation.",
+	},
+	"distinctive_descriptor_path_pattern": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "When set, 'descriptor_path_pattern' is used.",
+	},
+	"descriptor_path_pattern": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Please refer to: [Descriptor Path Patterns](https://www.jfrog.com/confluence/display/JFROG/Repository+Layouts#RepositoryLayouts-DescriptorPathPatterns) in the Artifactory Wiki documentation.",
+	},
+	"folder_integration_revision_regexp": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "A regular expression matching the integration revision string appearing in a folder name as part of the artifact's path.",
+	},
+	"file_integration_revision_regexp": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "A regular expression matching the integration revision string appearing in a file name as part of the artifact's path.",
+	},
+	"built_in": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Set to `true` when the layout is one of the layouts Artifactory ships with and uses as a package type default, e.g. `maven-2-default`.",
+	},
+}
+
+func packLayout(layout configuration.Layout) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                                layout.Name,
+		"artifact_path_pattern":               layout.ArtifactPathPattern,
+		"distinctive_descriptor_path_pattern": layout.DistinctiveDescriptorPathPattern,
+		"descriptor_path_pattern":             layout.DescriptorPathPattern,
+		"folder_integration_revision_regexp":  layout.FolderIntegrationRevisionRegExp,
+		"file_integration_revision_regexp":    layout.FileIntegrationRevisionRegExp,
+		"built_in":                            repository.IsDefaultRepoLayoutRef(layout.Name),
+	}
+}
+
+func getLayouts(m interface{}) ([]configuration.Layout, error) {
+	layouts := configuration.Layouts{}
+	_, err := m.(util.ProvderMetadata).Client.R().SetResult(&layouts).Get(ConfigurationEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return layouts.Layouts, nil
+}
+
+func DataSourceArtifactoryRepositoryLayout() *schema.Resource {
+	var dataSourceLayoutRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		name := d.Get("name").(string)
+
+		layouts, err := getLayouts(m)
+		if err != nil {
+			return diag.Errorf("failed to retrieve data from API: /%s during Read: %s", ConfigurationEndpoint, err)
+		}
+
+		matchedLayout := configuration.FindConfigurationById[configuration.Layout](layouts, name)
+		if matchedLayout == nil {
+			return diag.Errorf("repository layout '%s' not found", name)
+		}
+
+		d.SetId(matchedLayout.Name)
+
+		setValue := util.MkLens(d)
+		var errors []error
+		for key, value := range packLayout(*matchedLayout) {
+			errors = setValue(key, value)
+		}
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack repository layout %q", errors)
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceLayoutRead,
+		Schema: util.MergeMaps(
+			map[string]*schema.Schema{
+				"name": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+					Description:      "Layout name",
+				},
+			},
+			layoutSchema,
+		),
+		Description: "Provides the repository layout data source. Contains the definition of a specific layout, including the built-in layouts.",
+	}
+}
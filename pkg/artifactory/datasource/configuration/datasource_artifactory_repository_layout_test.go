@@ -0,0 +1,88 @@
+package configuration_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func TestAccDataSourceRepositoryLayout_builtIn(t *testing.T) {
+	_, tempFqrn, name := test.MkNames("maven-layout", "artifactory_repository_layout")
+	fqrn := "data." + tempFqrn
+
+	config := util.ExecuteTemplate("layout", `
+		data "artifactory_repository_layout" "{{ .name }}" {
+			name = "maven-2-default"
+		}
+	`, map[string]interface{}{
+		"name": name,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "name", "maven-2-default"),
+					resource.TestCheckResourceAttr(fqrn, "artifact_path_pattern", "[orgPath]/[module]/[baseRev](-[folderItegRev])/[module]-[baseRev](-[fileItegRev])(-[classifier]).[ext]"),
+					resource.TestCheckResourceAttr(fqrn, "distinctive_descriptor_path_pattern", "true"),
+					resource.TestCheckResourceAttr(fqrn, "built_in", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceRepositoryLayouts_full(t *testing.T) {
+	_, tempFqrn, name := test.MkNames("test", "artifactory_repository_layout")
+	layoutFqrn := "data." + tempFqrn
+	layoutsFqrn := "data.artifactory_repository_layouts.all"
+
+	config := util.ExecuteTemplate("layouts", `
+		resource "artifactory_repository_layout" "{{ .name }}" {
+			name                                = "{{ .name }}"
+			artifact_path_pattern               = "[orgPath]/[module]/[baseRev](-[folderItegRev])/[module]-[baseRev](-[fileItegRev])(-[classifier]).[ext]"
+			distinctive_descriptor_path_pattern = false
+			folder_integration_revision_regexp  = "SNAPSHOT"
+			file_integration_revision_regexp    = "SNAPSHOT|(?:(?:[0-9]{8}.[0-9]{6})-(?:[0-9]+))"
+		}
+
+		data "artifactory_repository_layout" "{{ .name }}" {
+			name = artifactory_repository_layout.{{ .name }}.name
+		}
+
+		data "artifactory_repository_layouts" "all" {
+			depends_on = [artifactory_repository_layout.{{ .name }}]
+		}
+	`, map[string]interface{}{
+		"name": name,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(layoutFqrn, "name", name),
+					resource.TestCheckResourceAttr(layoutFqrn, "folder_integration_revision_regexp", "SNAPSHOT"),
+					resource.TestCheckResourceAttr(layoutFqrn, "built_in", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs(layoutsFqrn, "layouts.*", map[string]string{
+						"name":     name,
+						"built_in": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(layoutsFqrn, "layouts.*", map[string]string{
+						"name":     "npm-default",
+						"built_in": "true",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,58 @@
+package configuration
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func DataSourceArtifactoryRepositoryLayouts() *schema.Resource {
+	var dataSourceLayoutsRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		layouts, err := getLayouts(m)
+		if err != nil {
+			return diag.Errorf("failed to retrieve data from API: /%s during Read: %s", ConfigurationEndpoint, err)
+		}
+
+		packedLayouts := []interface{}{}
+		for _, layout := range layouts {
+			packedLayouts = append(packedLayouts, packLayout(layout))
+		}
+
+		d.SetId(ConfigurationEndpoint)
+
+		if err := d.Set("layouts", packedLayouts); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	layoutResourceSchema := util.MergeMaps(
+		map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Layout name",
+			},
+		},
+		layoutSchema,
+	)
+
+	return &schema.Resource{
+		ReadContext: dataSourceLayoutsRead,
+		Schema: map[string]*schema.Schema{
+			"layouts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "All repository layouts defined in Artifactory, including the built-in layouts.",
+				Elem: &schema.Resource{
+					Schema: layoutResourceSchema,
+				},
+			},
+		},
+		Description: "Provides the repository layouts data source. Contains the definitions of all repository layouts, including the built-in layouts.",
+	}
+}
@@ -0,0 +1,404 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/repository"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/user"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const (
+	EffectiveItemPermissionsEndpoint = "artifactory/api/storage/{repoKey}/"
+	UserPermissionsEndpoint          = "artifactory/api/v2/security/permissions/users/{name}"
+	GroupPermissionsEndpoint         = "artifactory/api/v2/security/permissions/groups/{name}"
+)
+
+// effectiveItemPermissions is the response of the Effective Item Permissions API, the abbreviated actions of every
+// user and group on the item
+type effectiveItemPermissions struct {
+	Principals struct {
+		Users  map[string][]string `json:"users"`
+		Groups map[string][]string `json:"groups"`
+	} `json:"principals"`
+}
+
+// effectivePermissionActions are the names of the abbreviated actions of the Effective Item Permissions API
+var effectivePermissionActions = map[string]string{
+	"r": "read",
+	"w": "write",
+	"n": "annotate",
+	"d": "delete",
+	"m": "manage",
+}
+
+// EffectivePermissionAction returns the name of an abbreviated action. Unknown actions are returned unchanged.
+func EffectivePermissionAction(action string) string {
+	if name, ok := effectivePermissionActions[action]; ok {
+		return name
+	}
+	return action
+}
+
+// EffectiveItemPermissionsPath returns the path of the item in the Effective Item Permissions API, with its segments
+// escaped
+func EffectiveItemPermissionsPath(path string) string {
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+		segments = append(segments, url.PathEscape(segment))
+	}
+	return strings.Join(segments, "/")
+}
+
+// principalPermissions is the response of the user and group permissions endpoints. It lists every permission
+// target the principal is part of, with the actions granted to the principal only.
+type principalPermissions struct {
+	Name        string                                   `json:"name"`
+	Permissions map[string]principalPermissionTargetInfo `json:"permissions"`
+}
+
+type principalPermissionTargetInfo struct {
+	Repo *principalPermissionSection `json:"repo,omitempty"`
+}
+
+type principalPermissionSection struct {
+	IncludePatterns []string `json:"include-patterns"`
+	ExcludePatterns []string `json:"exclude-patterns"`
+	Repositories    []string `json:"repositories"`
+	Actions         []string `json:"actions"`
+}
+
+// effectivePrincipal is the user, or one of the groups, whose permissions are looked up
+type effectivePrincipal struct {
+	principalType string
+	name          string
+}
+
+type contributingPermissionTarget struct {
+	Name          string
+	PrincipalType string
+	Principal     string
+	Actions       []string
+}
+
+var antPatternReplacer = strings.NewReplacer(
+	`\*\*/`, `(.*/)?`,
+	`/\*\*`, `(/.*)?`,
+	`\*\*`, `.*`,
+	`\*`, `[^/]*`,
+	`\?`, `[^/]`,
+)
+
+// MatchesAntPattern reports whether the repository path matches the Ant-style pattern of a permission target,
+// where `**` matches any number of directories, `*` any characters but `/`, and `?` a single one of them.
+// Like in Artifactory, `dir/**` also matches `dir` itself.
+func MatchesAntPattern(pattern, path string) bool {
+	pattern = strings.Trim(pattern, "/")
+	path = strings.Trim(path, "/")
+
+	expression := antPatternReplacer.Replace(regexp.QuoteMeta(pattern))
+	matched, _ := regexp.MatchString("^"+expression+"$", path)
+	return matched
+}
+
+// PathMatchesPatterns applies the include and exclude patterns of a permission target to a repository path.
+// No include pattern, or only empty ones, is the same as `**`.
+func PathMatchesPatterns(path string, includePatterns, excludePatterns []string) bool {
+	included := true
+	for _, pattern := range includePatterns {
+		if pattern == "" {
+			continue
+		}
+		included = false
+		if MatchesAntPattern(pattern, path) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+
+	for _, pattern := range excludePatterns {
+		if pattern != "" && MatchesAntPattern(pattern, path) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// repositoryMatches checks the repositories of a permission target, which can also be one of the `ANY`,
+// `ANY LOCAL`, `ANY REMOTE` and `ANY DISTRIBUTION` wildcards
+func repositoryMatches(repositories []string, key, rclass string) bool {
+	for _, name := range repositories {
+		switch name {
+		case key, "ANY":
+			return true
+		case "ANY LOCAL":
+			if rclass == "local" || rclass == "federated" {
+				return true
+			}
+		case "ANY REMOTE":
+			if rclass == "remote" {
+				return true
+			}
+		case "ANY DISTRIBUTION":
+			if rclass == "distribution" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contributingPermissionTargets returns the permission targets of the principals whose repositories and
+// include/exclude patterns apply to the path, sorted by name and principal
+func contributingPermissionTargets(principals []effectivePrincipal, repoKey, path string, m interface{}) ([]contributingPermissionTarget, error) {
+	repo := struct {
+		Rclass string `json:"rclass"`
+	}{}
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("key", repoKey).
+		SetResult(&repo).
+		Get(repository.RepositoriesEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []contributingPermissionTarget
+	for _, p := range principals {
+		endpoint := UserPermissionsEndpoint
+		if p.principalType == "group" {
+			endpoint = GroupPermissionsEndpoint
+		}
+
+		permissions := principalPermissions{}
+		_, err := m.(util.ProvderMetadata).Client.R().
+			SetPathParam("name", p.name).
+			SetResult(&permissions).
+			Get(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve the permissions of %s '%s': %s", p.principalType, p.name, err)
+		}
+
+		for targetName, target := range permissions.Permissions {
+			if target.Repo == nil ||
+				!repositoryMatches(target.Repo.Repositories, repoKey, repo.Rclass) ||
+				!PathMatchesPatterns(path, target.Repo.IncludePatterns, target.Repo.ExcludePatterns) {
+				continue
+			}
+
+			targets = append(targets, contributingPermissionTarget{
+				Name:          targetName,
+				PrincipalType: p.principalType,
+				Principal:     p.name,
+				Actions:       target.Repo.Actions,
+			})
+		}
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Name != targets[j].Name {
+			return targets[i].Name < targets[j].Name
+		}
+		return targets[i].Principal < targets[j].Principal
+	})
+
+	return targets, nil
+}
+
+func DataSourceArtifactoryEffectivePermissions() *schema.Resource {
+	var dataSourceEffectivePermissionsRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		repoKey := d.Get("repository").(string)
+		path := d.Get("path").(string)
+
+		var principals []effectivePrincipal
+
+		if userName, ok := d.GetOk("user_name"); ok {
+			principals = append(principals, effectivePrincipal{"user", userName.(string)})
+
+			// the permissions are listed by principal, the user's own don't include those of the user's groups
+			u := user.User{}
+			_, err := m.(util.ProvderMetadata).Client.R().SetResult(&u).Get(user.UsersEndpointPath + userName.(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			for _, group := range u.Groups {
+				principals = append(principals, effectivePrincipal{"group", group})
+			}
+		} else {
+			principals = append(principals, effectivePrincipal{"group", d.Get("group_name").(string)})
+		}
+
+		permissions := effectiveItemPermissions{}
+		_, err := m.(util.ProvderMetadata).Client.R().
+			SetPathParam("repoKey", repoKey).
+			SetQueryParam("permissions", "").
+			SetResult(&permissions).
+			Get(EffectiveItemPermissionsEndpoint + EffectiveItemPermissionsPath(path))
+		if err != nil {
+			return diag.Errorf("failed to retrieve the effective permissions of %s/%s: %s", repoKey, strings.Trim(path, "/"), err)
+		}
+
+		effectiveActions := map[string]bool{}
+		var packedPrincipals []interface{}
+		for _, p := range principals {
+			principalActions := permissions.Principals.Users[p.name]
+			if p.principalType == "group" {
+				principalActions = permissions.Principals.Groups[p.name]
+			}
+			if len(principalActions) == 0 {
+				continue
+			}
+
+			var actions []interface{}
+			for _, action := range principalActions {
+				name := EffectivePermissionAction(action)
+				effectiveActions[name] = true
+				actions = append(actions, name)
+			}
+			packedPrincipals = append(packedPrincipals, map[string]interface{}{
+				"principal_type": p.principalType,
+				"principal":      p.name,
+				"actions":        schema.NewSet(schema.HashString, actions),
+			})
+		}
+
+		var actions []interface{}
+		for action := range effectiveActions {
+			actions = append(actions, action)
+		}
+
+		targets, err := contributingPermissionTargets(principals, repoKey, path, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		var packedTargets []interface{}
+		for _, target := range targets {
+			packedTargets = append(packedTargets, map[string]interface{}{
+				"name":           target.Name,
+				"principal_type": target.PrincipalType,
+				"principal":      target.Principal,
+				"actions":        schema.NewSet(schema.HashString, util.CastToInterfaceArr(target.Actions)),
+			})
+		}
+
+		principalName := principals[0].name
+		d.SetId(fmt.Sprintf("%s:%s:%s/%s", principals[0].principalType, principalName, repoKey, strings.Trim(path, "/")))
+
+		setValue := util.MkLens(d)
+		setValue("actions", schema.NewSet(schema.HashString, actions))
+		setValue("principals", packedPrincipals)
+		errors := setValue("permission_targets", packedTargets)
+		if errors != nil && len(errors) > 0 {
+			return diag.Errorf("failed to pack effective permissions %q", errors)
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceEffectivePermissionsRead,
+		Schema: map[string]*schema.Schema{
+			"user_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"user_name", "group_name"},
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Name of the user. The permissions of the groups the user is a member of are included.",
+			},
+			"group_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Name of the group.",
+			},
+			"repository": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Key of the repository.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Path of an existing item in the repository, e.g. `com/acme/app/1.0/app-1.0.jar`. Default is the root of the repository.",
+			},
+			"actions": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Actions the principal is allowed to perform on the path, e.g. `read`, `write`, `annotate`, `delete` or `manage`.",
+			},
+			"principals": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The user, and the groups of the user, granted actions on the path, with their actions.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"principal_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "`user`, or `group` when the actions are granted through a group of the user.",
+						},
+						"principal": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the user or group the actions are granted to.",
+						},
+						"actions": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Actions granted to the user or group.",
+						},
+					},
+				},
+			},
+			"permission_targets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Permission targets of the user, and of the groups of the user, whose repositories and include/exclude patterns apply to the path.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the permission target.",
+						},
+						"principal_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "`user` or `group`, when the permission target grants the actions through a group of the user.",
+						},
+						"principal": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the user or group the permission target grants the actions to.",
+						},
+						"actions": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Actions granted by the permission target.",
+						},
+					},
+				},
+			},
+		},
+		Description: "Provides the effective permissions of a user or a group on a repository path, as reported by the Effective Item Permissions API, " +
+			"with the permission targets granting them.",
+	}
+}
@@ -0,0 +1,240 @@
+package security_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/datasource/security"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/user"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func TestDataSourceEffectivePermissions(t *testing.T) {
+	var requestURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/"+user.UsersEndpointPath+"ci":
+			_ = json.NewEncoder(w).Encode(user.User{Name: "ci", Groups: []string{"readers", "deployers"}})
+		case r.URL.Path == "/artifactory/api/repositories/libs-release-local":
+			_, _ = w.Write([]byte(`{"key": "libs-release-local", "rclass": "local"}`))
+		case r.URL.Path == "/artifactory/api/v2/security/permissions/users/ci":
+			_, _ = w.Write([]byte(`{"name": "ci", "permissions": {
+				"ci-deploy": {"repo": {"include-patterns": ["com/acme/**"], "repositories": ["libs-release-local"], "actions": ["read", "write"]}},
+				"ci-other": {"repo": {"include-patterns": ["org/**"], "repositories": ["libs-release-local"], "actions": ["read"]}}
+			}}`))
+		case r.URL.Path == "/artifactory/api/v2/security/permissions/groups/readers":
+			_, _ = w.Write([]byte(`{"name": "readers", "permissions": {
+				"read-all": {"repo": {"repositories": ["ANY LOCAL"], "actions": ["read"]}}
+			}}`))
+		case r.URL.Path == "/artifactory/api/v2/security/permissions/groups/deployers":
+			_, _ = w.Write([]byte(`{"name": "deployers", "permissions": {
+				"deploy-remote": {"repo": {"repositories": ["ANY REMOTE"], "actions": ["write"]}}
+			}}`))
+		case strings.HasPrefix(r.URL.Path, "/artifactory/api/storage/"):
+			requestURI = r.URL.RequestURI()
+			_, _ = w.Write([]byte(`{
+				"uri": "http://localhost:8081/artifactory/api/storage/libs-release-local/com/acme/app%201.0",
+				"principals": {
+					"users": {"ci": ["r", "w"], "bob": ["m"]},
+					"groups": {"readers": ["r"], "maintainers": ["d"]}
+				}
+			}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataSource := security.DataSourceArtifactoryEffectivePermissions()
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"user_name":  "ci",
+		"repository": "libs-release-local",
+		"path":       "/com/acme/app 1.0",
+	})
+
+	if diags := dataSource.ReadContext(context.Background(), d, util.ProvderMetadata{Client: restyClient}); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if expected := "/artifactory/api/storage/libs-release-local/com/acme/app%201.0?permissions="; requestURI != expected {
+		t.Errorf("expected request %s, got %s", expected, requestURI)
+	}
+
+	actions := util.CastToStringArr(d.Get("actions").(*schema.Set).List())
+	sort.Strings(actions)
+	if !reflect.DeepEqual([]string{"read", "write"}, actions) {
+		t.Errorf("expected the read and write actions, got %v", actions)
+	}
+
+	principals := d.Get("principals").([]interface{})
+	if len(principals) != 2 {
+		t.Fatalf("expected the user and the readers group, got %v", principals)
+	}
+	for i, expected := range []string{"ci", "readers"} {
+		if principal := principals[i].(map[string]interface{})["principal"]; principal != expected {
+			t.Errorf("expected principal %s, got %v", expected, principal)
+		}
+	}
+
+	permissionTargets := d.Get("permission_targets").([]interface{})
+	if len(permissionTargets) != 2 {
+		t.Fatalf("expected the ci-deploy and read-all permission targets, got %v", permissionTargets)
+	}
+	for i, expected := range []string{"ci-deploy:ci", "read-all:readers"} {
+		target := permissionTargets[i].(map[string]interface{})
+		if actual := target["name"].(string) + ":" + target["principal"].(string); actual != expected {
+			t.Errorf("expected permission target %s, got %s", expected, actual)
+		}
+	}
+}
+
+func TestMatchesAntPattern(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		{pattern: "**", path: "", matches: true},
+		{pattern: "**", path: "com/acme/app/1.0/app-1.0.jar", matches: true},
+		{pattern: "com/acme/**", path: "com/acme", matches: true},
+		{pattern: "com/acme/**", path: "com/acme/app/1.0/app-1.0.jar", matches: true},
+		{pattern: "com/acme/**", path: "/com/acme/app/", matches: true},
+		{pattern: "com/acme/**", path: "com/acmeco/app", matches: false},
+		{pattern: "**/*.jar", path: "app-1.0.jar", matches: true},
+		{pattern: "**/*.jar", path: "com/acme/app-1.0.jar", matches: true},
+		{pattern: "**/*.jar", path: "com/acme/app-1.0.pom", matches: false},
+		{pattern: "com/*/app", path: "com/acme/app", matches: true},
+		{pattern: "com/*/app", path: "com/acme/sub/app", matches: false},
+		{pattern: "com/**/app", path: "com/app", matches: true},
+		{pattern: "com/**/app", path: "com/acme/sub/app", matches: true},
+		{pattern: "app-1.?.jar", path: "app-1.0.jar", matches: true},
+		{pattern: "app-1.?.jar", path: "app-1.10.jar", matches: false},
+		{pattern: "app+(1).jar", path: "app+(1).jar", matches: true},
+	}
+
+	for _, testCase := range testCases {
+		if actual := security.MatchesAntPattern(testCase.pattern, testCase.path); actual != testCase.matches {
+			t.Errorf("pattern %q on path %q: expected %t, got %t", testCase.pattern, testCase.path, testCase.matches, actual)
+		}
+	}
+}
+
+func TestPathMatchesPatterns(t *testing.T) {
+	testCases := []struct {
+		path     string
+		includes []string
+		excludes []string
+		matches  bool
+	}{
+		{path: "com/acme/app", includes: nil, excludes: nil, matches: true},
+		{path: "com/acme/app", includes: []string{""}, excludes: []string{""}, matches: true},
+		{path: "com/acme/app", includes: []string{"org/**", "com/**"}, excludes: nil, matches: true},
+		{path: "com/acme/app", includes: []string{"org/**"}, excludes: nil, matches: false},
+		{path: "com/acme/app", includes: []string{"**"}, excludes: []string{"com/acme/**"}, matches: false},
+	}
+
+	for _, testCase := range testCases {
+		if actual := security.PathMatchesPatterns(testCase.path, testCase.includes, testCase.excludes); actual != testCase.matches {
+			t.Errorf("path %q with includes %v and excludes %v: expected %t, got %t", testCase.path, testCase.includes, testCase.excludes, testCase.matches, actual)
+		}
+	}
+}
+
+func TestEffectivePermissionAction(t *testing.T) {
+	for action, expected := range map[string]string{"r": "read", "w": "write", "n": "annotate", "d": "delete", "m": "manage", "mxm": "mxm"} {
+		if actual := security.EffectivePermissionAction(action); actual != expected {
+			t.Errorf("action %q: expected %q, got %q", action, expected, actual)
+		}
+	}
+}
+
+func TestAccDataSourceEffectivePermissions_user(t *testing.T) {
+	_, _, permName := test.MkNames("test-perm", "artifactory_permission_target")
+	_, _, repoName := test.MkNames("test-perm-repo", "artifactory_local_generic_repository")
+	_, _, userName := test.MkNames("test-perm-user", "artifactory_managed_user")
+	_, fqrn, name := test.MkNames("effective-permissions", "data.artifactory_effective_permissions")
+
+	tempStruct := map[string]string{
+		"repo_name":       repoName,
+		"permission_name": permName,
+		"user_name":       userName,
+		"name":            name,
+	}
+
+	const config = `
+	resource "artifactory_local_generic_repository" "{{ .repo_name }}" {
+		key = "{{ .repo_name }}"
+	}
+
+	resource "artifactory_managed_user" "{{ .user_name }}" {
+		name     = "{{ .user_name }}"
+		email    = "{{ .user_name }}@tempurl.org"
+		password = "Passw0rd!123"
+		groups   = ["readers"]
+	}
+
+	resource "artifactory_permission_target" "{{ .permission_name }}" {
+		name = "{{ .permission_name }}"
+
+		repo {
+			repositories = [artifactory_local_generic_repository.{{ .repo_name }}.key]
+
+			actions {
+				users {
+					name        = artifactory_managed_user.{{ .user_name }}.name
+					permissions = ["read", "write"]
+				}
+			}
+		}
+	}
+
+	data "artifactory_effective_permissions" "{{ .name }}" {
+		user_name  = artifactory_managed_user.{{ .user_name }}.name
+		repository = artifactory_local_generic_repository.{{ .repo_name }}.key
+
+		depends_on = [artifactory_permission_target.{{ .permission_name }}]
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, config, tempStruct),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(fqrn, "actions.*", "read"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "actions.*", "write"),
+					resource.TestCheckTypeSetElemNestedAttrs(fqrn, "principals.*", map[string]string{
+						"principal_type": "user",
+						"principal":      userName,
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(fqrn, "permission_targets.*", map[string]string{
+						"name":           permName,
+						"principal_type": "user",
+						"principal":      userName,
+					}),
+				),
+			},
+		},
+	})
+}
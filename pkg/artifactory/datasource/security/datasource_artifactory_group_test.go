@@ -122,3 +122,41 @@ func TestAccGroup_basic_datasource_includeusers_true(t *testing.T) {
 		},
 	})
 }
+
+func TestAccGroups_filter_datasource(t *testing.T) {
+	_, fqrn, name := test.MkNames("test-groups", "data.artifactory_groups")
+	groupName := name + "-group"
+	externalId := "external-" + name
+
+	temp := `
+		data "artifactory_groups" "{{ .name }}" {
+			external_id = "{{ .externalId }}"
+		}
+	`
+	config := util.ExecuteTemplate(name, temp, map[string]string{
+		"name":       name,
+		"externalId": externalId,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			createGroup(groupName, "test-groups filter", externalId, t)
+		},
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy: func(state *terraform.State) error {
+			return deleteGroup(t, groupName)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "groups.#", "1"),
+					resource.TestCheckResourceAttr(fqrn, "groups.0.name", groupName),
+					resource.TestCheckResourceAttr(fqrn, "groups.0.external_id", externalId),
+					resource.TestCheckResourceAttr(fqrn, "groups.0.watch_manager", "true"),
+				),
+			},
+		},
+	})
+}
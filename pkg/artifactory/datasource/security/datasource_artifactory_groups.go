@@ -0,0 +1,100 @@
+package security
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/security"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func DataSourceArtifactoryGroups() *schema.Resource {
+	dataSourceGroupsRead := func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		filter := security.GroupFilter{
+			Realm:      d.Get("realm").(string),
+			ExternalId: d.Get("external_id").(string),
+		}
+
+		names, err := security.ListGroupNames(m)
+		if err != nil {
+			return diag.Errorf("failed to retrieve data from API: /%s during Read: %s", security.AccessGroupsEndpoint, err)
+		}
+
+		packedGroups := []interface{}{}
+		for _, name := range names {
+			group := security.Group{}
+			_, err := m.(util.ProvderMetadata).Client.R().SetResult(&group).Get(security.GroupsEndpoint + name)
+			if err != nil {
+				return diag.Errorf("failed to retrieve group %s: %s", name, err)
+			}
+
+			if !filter.Matches(group) {
+				continue
+			}
+
+			packedGroups = append(packedGroups, map[string]interface{}{
+				"name":             group.Name,
+				"description":      group.Description,
+				"external_id":      group.ExternalId,
+				"auto_join":        group.AutoJoin,
+				"admin_privileges": group.AdminPrivileges,
+				"realm":            group.Realm,
+				"realm_attributes": group.RealmAttributes,
+				"watch_manager":    group.WatchManager,
+				"policy_manager":   group.PolicyManager,
+				"reports_manager":  group.ReportsManager,
+			})
+		}
+
+		d.SetId(security.AccessGroupsEndpoint)
+
+		if err := d.Set("groups", packedGroups); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	groupElemSchema := map[string]*schema.Schema{}
+	for _, key := range []string{"name", "description", "external_id", "realm", "realm_attributes"} {
+		groupElemSchema[key] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: security.GroupSchema[key].Description,
+		}
+	}
+	for _, key := range []string{"auto_join", "admin_privileges", "watch_manager", "policy_manager", "reports_manager"} {
+		groupElemSchema[key] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: security.GroupSchema[key].Description,
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceGroupsRead,
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only the groups of this realm, e.g. `internal`, `ldap` or `saml`.",
+			},
+			"external_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only the group with this external ID, e.g. the Azure AD object ID of the group.",
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The groups matching all the filters.",
+				Elem: &schema.Resource{
+					Schema: groupElemSchema,
+				},
+			},
+		},
+		Description: "Provides the Artifactory groups data source. Lists the groups, filtered by realm or external ID.",
+	}
+}
@@ -63,6 +63,16 @@ func DataSourceArtifactoryUser() *schema.Resource {
 			Optional:    true,
 			Description: "List of groups this user is a part of.",
 		},
+		"last_logged_in": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Time of the last login of the user.",
+		},
+		"realm": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Authentication realm of the user, e.g. `internal`, `ldap` or `saml`.",
+		},
 	}
 
 	read := func(_ context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
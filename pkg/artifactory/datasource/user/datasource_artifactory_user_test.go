@@ -50,3 +50,56 @@ func TestAccDataSourceUser_basic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceUsers_filters(t *testing.T) {
+	id := test.RandomInt()
+	name := fmt.Sprintf("foobar-%d", id)
+	email := name + "@test.com"
+
+	temp := `
+		data "artifactory_users" "{{ .name }}" {
+			realm         = "internal"
+			admin         = false
+			group         = "readers"
+			inactive_days = 1
+		}
+
+		data "artifactory_users" "{{ .name }}-admins" {
+			admin = true
+		}
+	`
+
+	config := util.ExecuteTemplate(name, temp, map[string]string{"name": name})
+	fqrn := fmt.Sprintf("data.artifactory_users.%s", name)
+	adminsFqrn := fmt.Sprintf("data.artifactory_users.%s-admins", name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			acctest.CreateUserUpdatable(t, name, email)
+		},
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy: func(state *terraform.State) error {
+			return acctest.DeleteUser(t, name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					// the new user never logged in and is a member of the default "readers" group
+					resource.TestCheckTypeSetElemNestedAttrs(fqrn, "users.*", map[string]string{
+						"name":     name,
+						"email":    email,
+						"admin":    "false",
+						"realm":    "internal",
+						"disabled": "false",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(adminsFqrn, "users.*", map[string]string{
+						"name":  "admin",
+						"admin": "true",
+					}),
+				),
+			},
+		},
+	})
+}
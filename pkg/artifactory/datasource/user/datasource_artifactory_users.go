@@ -0,0 +1,156 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/user"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+func DataSourceArtifactoryUsers() *schema.Resource {
+	dataSourceUsersRead := func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		filter := user.UserFilter{
+			Realm:        d.Get("realm").(string),
+			Group:        d.Get("group").(string),
+			InactiveDays: d.Get("inactive_days").(int),
+		}
+		// GetOk can't tell an unset boolean from false
+		if admin, ok := d.GetOkExists("admin"); ok {
+			isAdmin := admin.(bool)
+			filter.Admin = &isAdmin
+		}
+
+		summaries, err := user.ListUsers(m)
+		if err != nil {
+			return diag.Errorf("failed to retrieve data from API: /%s during Read: %s", user.AccessUsersEndpoint, err)
+		}
+
+		now := time.Now()
+		packedUsers := []interface{}{}
+		for _, summary := range summaries {
+			// the realm is part of the listing, the details are only retrieved for the users of the realm
+			if !filter.MatchesRealm(summary.Realm) {
+				continue
+			}
+
+			userObj := user.User{}
+			_, err := m.(util.ProvderMetadata).Client.R().SetResult(&userObj).Get(user.UsersEndpointPath + summary.Name)
+			if err != nil {
+				return diag.Errorf("failed to retrieve user %s: %s", summary.Name, err)
+			}
+
+			matches, err := filter.Matches(userObj, now)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if !matches {
+				continue
+			}
+
+			packedUsers = append(packedUsers, map[string]interface{}{
+				"name":                       userObj.Name,
+				"email":                      userObj.Email,
+				"admin":                      userObj.Admin,
+				"profile_updatable":          userObj.ProfileUpdatable,
+				"disable_ui_access":          userObj.DisableUIAccess,
+				"internal_password_disabled": userObj.InternalPasswordDisabled,
+				"groups":                     schema.NewSet(schema.HashString, util.CastToInterfaceArr(userObj.Groups)),
+				"last_logged_in":             userObj.LastLoggedIn,
+				"realm":                      userObj.Realm,
+				"disabled":                   summary.Status == user.UserStatusDisabled,
+			})
+		}
+
+		d.SetId(user.AccessUsersEndpoint)
+
+		if err := d.Set("users", packedUsers); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceUsersRead,
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only the users of this authentication realm, e.g. `internal`, `ldap` or `saml`.",
+			},
+			"admin": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only the administrators if `true`, or only the other users if `false`. All the users when not set.",
+			},
+			"group": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only the members of this group.",
+			},
+			"inactive_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validator.IntAtLeast(1),
+				Description:      "Only the users who haven't logged in for at least this number of days, including those who never logged in.",
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The users matching all the filters.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"admin": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"profile_updatable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"disable_ui_access": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"internal_password_disabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"groups": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+							Computed: true,
+						},
+						"last_logged_in": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"realm": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"disabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Description: "Provides the Artifactory users data source. Lists the users, filtered by realm, admin flag, group or last login.",
+	}
+}
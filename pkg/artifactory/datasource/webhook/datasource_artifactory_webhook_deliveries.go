@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/webhook"
+)
+
+func DataSourceArtifactoryWebhookDeliveries() *schema.Resource {
+	dataSourceWebhookDeliveriesRead := func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		key := d.Get("key").(string)
+
+		deliveries, err := webhook.ListDeliveries(key, d.Get("limit").(int), m)
+		if err != nil {
+			return diag.Errorf("failed to retrieve the deliveries of webhook %s: %s", key, err)
+		}
+
+		failedOnly := d.Get("failed_only").(bool)
+		packedDeliveries := []interface{}{}
+		failureCount := 0
+		for _, delivery := range deliveries {
+			if !delivery.Success {
+				failureCount++
+			} else if failedOnly {
+				continue
+			}
+
+			packedDeliveries = append(packedDeliveries, map[string]interface{}{
+				"id":          delivery.Id,
+				"event_type":  delivery.EventType,
+				"url":         delivery.Url,
+				"success":     delivery.Success,
+				"status_code": delivery.StatusCode,
+				"error":       delivery.Error,
+				"timestamp":   delivery.Timestamp,
+			})
+		}
+
+		d.SetId(key)
+
+		if err := d.Set("deliveries", packedDeliveries); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("failure_count", failureCount); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Usage of Undocumented Artifactory API Endpoints",
+			Detail:   "The artifactory_webhook_deliveries data source uses endpoints that are undocumented and may not work with SaaS environments, or may change without notice.",
+		}}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceWebhookDeliveriesRead,
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+				Description:      "Key of the webhook.",
+			},
+			"limit": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          25,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 1000)),
+				Description:      "Number of the most recent deliveries to read. Default is 25.",
+			},
+			"failed_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only the deliveries which failed.",
+			},
+			"failure_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of the deliveries which failed, among the deliveries read.",
+			},
+			"deliveries": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The deliveries, first the most recent.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the delivery.",
+						},
+						"event_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the event delivered, e.g. `deployed`.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URL of the handler the event was sent to.",
+						},
+						"success": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the handler accepted the event.",
+						},
+						"status_code": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "HTTP status code returned by the handler. 0 when the handler couldn't be reached.",
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Error of the delivery which failed.",
+						},
+						"timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time of the delivery.",
+						},
+					},
+				},
+			},
+		},
+		Description: "Provides the Artifactory webhook deliveries data source. Lists the recent attempts to deliver the events of a webhook to its handlers, and their failures.",
+	}
+}
@@ -0,0 +1,50 @@
+package webhook_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func TestAccWebhookDeliveries_datasource(t *testing.T) {
+	_, fqrn, name := test.MkNames("webhook-", "artifactory_webhook_deliveries")
+	temp := `
+		resource "artifactory_artifact_webhook" "{{ .name }}" {
+			key         = "{{ .name }}"
+			event_types = ["deployed"]
+			criteria {
+				any_local  = true
+				any_remote = false
+				repo_keys  = []
+			}
+			handler {
+				url = "https://tempurl.org"
+			}
+		}
+
+		data "artifactory_webhook_deliveries" "{{ .name }}" {
+			key         = artifactory_artifact_webhook.{{ .name }}.key
+			limit       = 10
+			failed_only = true
+		}
+	`
+	config := util.ExecuteTemplate(name, temp, map[string]string{"name": name})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data."+fqrn, "key", name),
+					resource.TestCheckResourceAttrSet("data."+fqrn, "failure_count"),
+					resource.TestCheckResourceAttrSet("data."+fqrn, "deliveries.#"),
+				),
+			},
+		},
+	})
+}
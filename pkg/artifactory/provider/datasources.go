@@ -5,12 +5,14 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/datasource"
+	datasource_configuration "github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/datasource/configuration"
 	datasource_federated "github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/datasource/repository/federated"
 	datasource_local "github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/datasource/repository/local"
 	datasource_remote "github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/datasource/repository/remote"
 	datasource_virtual "github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/datasource/repository/virtual"
 	datasource_security "github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/datasource/security"
 	datasource_user "github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/datasource/user"
+	datasource_webhook "github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/datasource/webhook"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/repository"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/repository/federated"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/repository/local"
@@ -23,9 +25,17 @@ func datasourcesMap() map[string]*schema.Resource {
 	dataSourcesMap := map[string]*schema.Resource{
 		"artifactory_file":                                    datasource.ArtifactoryFile(),
 		"artifactory_fileinfo":                                datasource.ArtifactoryFileInfo(),
+		"artifactory_effective_permissions":                   datasource_security.DataSourceArtifactoryEffectivePermissions(),
 		"artifactory_group":                                   datasource_security.DataSourceArtifactoryGroup(),
+		"artifactory_groups":                                  datasource_security.DataSourceArtifactoryGroups(),
 		"artifactory_permission_target":                       datasource_security.DataSourceArtifactoryPermissionTarget(),
+		"artifactory_property_set":                            datasource_configuration.DataSourceArtifactoryPropertySet(),
+		"artifactory_property_sets":                           datasource_configuration.DataSourceArtifactoryPropertySets(),
+		"artifactory_repository_layout":                       datasource_configuration.DataSourceArtifactoryRepositoryLayout(),
+		"artifactory_repository_layouts":                      datasource_configuration.DataSourceArtifactoryRepositoryLayouts(),
 		"artifactory_user":                                    datasource_user.DataSourceArtifactoryUser(),
+		"artifactory_users":                                   datasource_user.DataSourceArtifactoryUsers(),
+		"artifactory_webhook_deliveries":                      datasource_webhook.DataSourceArtifactoryWebhookDeliveries(),
 		"artifactory_local_alpine_repository":                 datasource_local.DataSourceArtifactoryLocalAlpineRepository(),
 		"artifactory_local_cargo_repository":                  datasource_local.DataSourceArtifactoryLocalCargoRepository(),
 		"artifactory_local_debian_repository":                 datasource_local.DataSourceArtifactoryLocalDebianRepository(),
@@ -66,11 +66,16 @@ func resourcesMap() map[string]*schema.Resource {
 		"artifactory_virtual_rpm_repository":                  virtual.ResourceArtifactoryVirtualRpmRepository(),
 		"artifactory_virtual_helm_repository":                 virtual.ResourceArtifactoryVirtualHelmRepository(),
 		"artifactory_group":                                   security.ResourceArtifactoryGroup(),
+		"artifactory_group_members":                           security.ResourceArtifactoryGroupMembers(),
+		"artifactory_group_member":                            security.ResourceArtifactoryGroupMember(),
 		"artifactory_user":                                    user.ResourceArtifactoryUser(),
 		"artifactory_unmanaged_user":                          user.ResourceArtifactoryUser(), // alias of artifactory_user
 		"artifactory_managed_user":                            user.ResourceArtifactoryManagedUser(),
 		"artifactory_anonymous_user":                          user.ResourceArtifactoryAnonymousUser(),
+		"artifactory_service_account":                         user.ResourceArtifactoryServiceAccount(),
+		"artifactory_users_bulk":                              user.ResourceArtifactoryUsersBulk(),
 		"artifactory_permission_target":                       security.ResourceArtifactoryPermissionTarget(),
+		"artifactory_permission":                              security.ResourceArtifactoryPermission(),
 		"artifactory_pull_replication":                        replication.ResourceArtifactoryPullReplication(),
 		"artifactory_push_replication":                        replication.ResourceArtifactoryPushReplication(),
 		"artifactory_local_repository_single_replication":     replication.ResourceArtifactoryLocalRepositorySingleReplication(),
@@ -81,6 +86,8 @@ func resourcesMap() map[string]*schema.Resource {
 		"artifactory_access_token":                            security.ResourceArtifactoryAccessToken(),
 		"artifactory_scoped_token":                            security.ResourceArtifactoryScopedToken(),
 		"artifactory_general_security":                        configuration.ResourceArtifactoryGeneralSecurity(),
+		"artifactory_password_policy":                         configuration.ResourceArtifactoryPasswordPolicy(),
+		"artifactory_user_lock_policy":                        configuration.ResourceArtifactoryUserLockPolicy(),
 		"artifactory_oauth_settings":                          configuration.ResourceArtifactoryOauthSettings(),
 		"artifactory_saml_settings":                           configuration.ResourceArtifactorySamlSettings(),
 		"artifactory_permission_targets":                      security.ResourceArtifactoryPermissionTargets(), // Deprecated. Remove in V7
@@ -132,6 +139,9 @@ func resourcesMap() map[string]*schema.Resource {
 	for _, webhookType := range webhook.TypesSupported {
 		webhookResourceName := fmt.Sprintf("artifactory_%s_webhook", webhookType)
 		resourcesMap[webhookResourceName] = webhook.ResourceArtifactoryWebhook(webhookType)
+
+		customWebhookResourceName := fmt.Sprintf("artifactory_%s_custom_webhook", webhookType)
+		resourcesMap[customWebhookResourceName] = webhook.ResourceArtifactoryCustomWebhook(webhookType)
 	}
 
 	return util.AddTelemetry(productId, resourcesMap)
@@ -0,0 +1,161 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const SystemExportEndpoint = "artifactory/api/export/system"
+
+const (
+	BackupExportStatusSucceeded = "succeeded"
+	BackupExportStatusFailed    = "failed"
+)
+
+var backupExportSchema = map[string]*schema.Schema{
+	"export_on_apply": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Description: "When set, the instance is exported to `export_path` with the settings of the backup config every time " +
+			"the backup config is created or updated, and the apply fails if the export fails. The REST API can't run the " +
+			"scheduled backup, so the export is run by the provider with the System Export API instead. This API can't exclude " +
+			"repositories: the export includes `excluded_repositories`, and the apply warns about it.",
+	},
+	"export_path": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Path on the Artifactory server the instance is exported to when `export_on_apply` is set.",
+	},
+	"last_export_time": {
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: "Time, in RFC 3339 format, at which the last export run by the provider for `export_on_apply` finished. " +
+			"It isn't the last run of the scheduled backup, which the REST API doesn't expose.",
+	},
+	"last_export_status": {
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: fmt.Sprintf("Status of the last export run by the provider for `export_on_apply`: `%s` or `%s`. "+
+			"It isn't the status of the last run of the scheduled backup, which the REST API doesn't expose.", BackupExportStatusSucceeded, BackupExportStatusFailed),
+	},
+}
+
+type systemExportSettings struct {
+	ExportPath      string `json:"exportPath"`
+	IncludeMetadata bool   `json:"includeMetadata"`
+	CreateArchive   bool   `json:"createArchive"`
+	BypassFiltering bool   `json:"bypassFiltering"`
+	Verbose         bool   `json:"verbose"`
+	FailOnError     bool   `json:"failOnError"`
+	FailIfEmpty     bool   `json:"failIfEmpty"`
+	M2              bool   `json:"m2"`
+	Incremental     bool   `json:"incremental"`
+	ExcludeContent  bool   `json:"excludeContent"`
+}
+
+// ExportBackup exports the Artifactory instance to exportPath with the System Export API, and waits for the export to
+// finish. The export has the archive and incremental settings of the backup config, includes the metadata like
+// backups do, and fails on the first error so the apply reports it. The System Export API can't exclude repositories,
+// so the excluded repositories of the backup config are exported too.
+//
+// There is no endpoint to poll the status of an export: the System Export API answers once the export has finished, so
+// the request is kept open until timeout. While Artifactory rejects the export because another export or a scheduled
+// backup is already running, the export is retried until timeout.
+func ExportBackup(ctx context.Context, backup Backup, exportPath string, timeout time.Duration, m interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	settings := systemExportSettings{
+		ExportPath:      exportPath,
+		IncludeMetadata: true,
+		CreateArchive:   backup.CreateArchive,
+		FailOnError:     true,
+		// backups without retention period are incremental
+		Incremental: backup.RetentionPeriodHours == 0,
+	}
+
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		resp, err := m.(util.ProvderMetadata).Client.R().
+			SetContext(ctx).
+			SetBody(settings).
+			Post(SystemExportEndpoint)
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusConflict {
+				return resource.RetryableError(fmt.Errorf("waiting for the running export to finish before exporting backup '%s'", backup.Key))
+			}
+			return resource.NonRetryableError(fmt.Errorf("export of backup '%s' to '%s' failed: %s", backup.Key, exportPath, err))
+		}
+
+		return nil
+	})
+}
+
+// exportBackupOnApply exports the instance when `export_on_apply` is set and records the result in the computed
+// attributes, which are kept in the state even when the export fails. It warns when the export includes repositories
+// excluded from the backup.
+func exportBackupOnApply(ctx context.Context, d *schema.ResourceData, backup Backup, m interface{}) diag.Diagnostics {
+	if !d.Get("export_on_apply").(bool) {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	if len(backup.ExcludedRepositories) > 0 || backup.ExcludeNewRepositories {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "The export includes the repositories excluded from the backup",
+			Detail: fmt.Sprintf("The System Export API used for export_on_apply can't exclude repositories, so the export of "+
+				"backup '%s' includes all the repositories, and doesn't verify excluded_repositories and exclude_new_repositories.", backup.Key),
+		})
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+
+	exportErr := ExportBackup(ctx, backup, d.Get("export_path").(string), timeout, m)
+
+	status := BackupExportStatusSucceeded
+	if exportErr != nil {
+		status = BackupExportStatusFailed
+	}
+
+	setValue := util.MkLens(d)
+	setValue("last_export_time", time.Now().UTC().Format(time.RFC3339))
+	if errors := setValue("last_export_status", status); len(errors) > 0 {
+		return append(diags, diag.Errorf("failed to save backup export status %q", errors)...)
+	}
+
+	if exportErr != nil {
+		return append(diags, diag.FromErr(exportErr)...)
+	}
+
+	return diags
+}
+
+func backupExportDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.Get("export_on_apply").(bool) {
+		return nil
+	}
+
+	if diff.Get("export_path").(string) == "" {
+		return fmt.Errorf("export_path must be set when export_on_apply is enabled")
+	}
+
+	// an export only runs when the backup config is created or changed
+	if diff.Id() == "" || len(diff.GetChangedKeysPrefix("")) > 0 {
+		if err := diff.SetNewComputed("last_export_time"); err != nil {
+			return err
+		}
+		return diff.SetNewComputed("last_export_status")
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package configuration_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/configuration"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func TestExportBackup(t *testing.T) {
+	var settings map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+configuration.SystemExportEndpoint {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backup := configuration.Backup{Key: "incremental", CreateArchive: true, RetentionPeriodHours: 0}
+	if err := configuration.ExportBackup(context.Background(), backup, "/tmp/incremental", time.Minute, util.ProvderMetadata{Client: restyClient}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"exportPath":      "/tmp/incremental",
+		"includeMetadata": true,
+		"createArchive":   true,
+		"failOnError":     true,
+		"incremental":     true,
+	}
+	for key, value := range expected {
+		if settings[key] != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, settings[key])
+		}
+	}
+}
+
+func TestBackupExportOnApplyWithExcludedRepositories(t *testing.T) {
+	exported := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/"+configuration.SystemExportEndpoint:
+			exported = true
+		case r.Method == http.MethodGet:
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<config><backups><backup><key>excluded-repositories</key><cronExp>0 0 12 * * ? *</cronExp>` +
+				`<excludedRepositories><repositoryRef>example-repo-local</repositoryRef></excludedRepositories></backup></backups></config>`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backup := configuration.ResourceArtifactoryBackup()
+	d := schema.TestResourceDataRaw(t, backup.Schema, map[string]interface{}{
+		"key":                   "excluded-repositories",
+		"cron_exp":              "0 0 12 * * ? *",
+		"excluded_repositories": []interface{}{"example-repo-local"},
+		"export_on_apply":       true,
+		"export_path":           "/tmp/excluded-repositories",
+	})
+
+	diags := backup.CreateContext(context.Background(), d, util.ProvderMetadata{Client: restyClient})
+	if diags.HasError() {
+		t.Fatalf("expected the backup to be exported, got: %v", diags)
+	}
+	if !exported {
+		t.Error("expected the instance to be exported")
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "excluded_repositories") {
+		t.Errorf("expected a warning about the excluded repositories, got: %v", diags)
+	}
+	if status := d.Get("last_export_status"); status != configuration.BackupExportStatusSucceeded {
+		t.Errorf("expected last_export_status to be %s, got %v", configuration.BackupExportStatusSucceeded, status)
+	}
+}
@@ -0,0 +1,135 @@
+package configuration
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// These are the endpoints behind the "Test" buttons of the LDAP settings screens in the UI. There is no public
+// REST API equivalent, and unlike the system configuration PATCH they take the candidate settings in JSON.
+const (
+	LdapSettingTestEndpoint      = "artifactory/ui/ldap/test"
+	LdapGroupSettingTestEndpoint = "artifactory/ui/ldapgroups/search"
+)
+
+// ldapTestEndpointWarning is returned whenever the settings are tested, as the UI endpoints above are undocumented.
+func ldapTestEndpointWarning(resourceName string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Usage of Undocumented Artifactory API Endpoints",
+		Detail:   fmt.Sprintf("The test_username attribute of the %s resource uses endpoints that are undocumented and may not work with SaaS environments, or may change without notice.", resourceName),
+	}}
+}
+
+var testUsernameSchema = map[string]*schema.Schema{
+	"test_username": {
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: "When set, the settings are tested against the LDAP server before being applied, and the apply is aborted if the test fails. " +
+			"The value is only used by Terraform and is never stored in Artifactory.",
+	},
+}
+
+type ldapSearchTestPayload struct {
+	SearchFilter    string `json:"searchFilter"`
+	SearchBase      string `json:"searchBase"`
+	SearchSubTree   bool   `json:"searchSubTree"`
+	ManagerDn       string `json:"managerDn"`
+	ManagerPassword string `json:"managerPassword,omitempty"`
+}
+
+type ldapSettingTestPayload struct {
+	Key                     string                `json:"key"`
+	Enabled                 bool                  `json:"enabled"`
+	LdapUrl                 string                `json:"ldapUrl"`
+	UserDnPattern           string                `json:"userDnPattern"`
+	EmailAttribute          string                `json:"emailAttribute"`
+	LdapPoisoningProtection bool                  `json:"ldapPoisoningProtection"`
+	PagingSupportEnabled    bool                  `json:"pagingSupportEnabled"`
+	Search                  ldapSearchTestPayload `json:"search"`
+	TestUsername            string                `json:"testUsername"`
+}
+
+type ldapGroupSettingTestPayload struct {
+	Name                 string `json:"name"`
+	EnabledLdap          string `json:"enabledLdap"`
+	GroupBaseDn          string `json:"groupBaseDn"`
+	GroupNameAttribute   string `json:"groupNameAttribute"`
+	GroupMemberAttribute string `json:"groupMemberAttribute"`
+	SubTree              bool   `json:"subTree"`
+	Filter               string `json:"filter"`
+	DescriptionAttribute string `json:"descriptionAttribute"`
+	Strategy             string `json:"strategy"`
+	Username             string `json:"username"`
+}
+
+type ldapGroupTestResult struct {
+	Name string `json:"groupName"`
+}
+
+// VerifyLdapSetting binds to the LDAP server with the candidate settings and looks up the test user.
+// The manager password is only sent when it is set in the configuration, otherwise Artifactory
+// uses the stored one.
+func VerifyLdapSetting(ldapSetting LdapSetting, testUsername string, m interface{}) error {
+	payload := ldapSettingTestPayload{
+		Key:                     ldapSetting.Key,
+		Enabled:                 ldapSetting.Enabled,
+		LdapUrl:                 ldapSetting.LdapUrl,
+		UserDnPattern:           ldapSetting.UserDnPattern,
+		EmailAttribute:          ldapSetting.EmailAttribute,
+		LdapPoisoningProtection: ldapSetting.LdapPoisoningProtection,
+		PagingSupportEnabled:    ldapSetting.PagingSupportEnabled,
+		Search: ldapSearchTestPayload{
+			SearchFilter:    ldapSetting.Search.SearchFilter,
+			SearchBase:      ldapSetting.Search.SearchBase,
+			SearchSubTree:   ldapSetting.Search.SearchSubTree,
+			ManagerDn:       ldapSetting.Search.ManagerDn,
+			ManagerPassword: ldapSetting.Search.ManagerPassword,
+		},
+		TestUsername: testUsername,
+	}
+
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetBody(payload).
+		Post(LdapSettingTestEndpoint)
+	if err != nil {
+		return fmt.Errorf("LDAP connection test for ldap setting '%s' with user '%s' failed: %s", ldapSetting.Key, testUsername, err)
+	}
+
+	return nil
+}
+
+// VerifyLdapGroupSetting searches the LDAP server for the groups of the test user with the candidate settings.
+// Finding no group is reported as a failure, as it is indistinguishable from a wrong base DN or filter.
+func VerifyLdapGroupSetting(ldapGroupSetting LdapGroupSetting, testUsername string, m interface{}) error {
+	payload := ldapGroupSettingTestPayload{
+		Name:                 ldapGroupSetting.Name,
+		EnabledLdap:          ldapGroupSetting.EnabledLdap,
+		GroupBaseDn:          ldapGroupSetting.GroupBaseDn,
+		GroupNameAttribute:   ldapGroupSetting.GroupNameAttribute,
+		GroupMemberAttribute: ldapGroupSetting.GroupMemberAttribute,
+		SubTree:              ldapGroupSetting.SubTree,
+		Filter:               ldapGroupSetting.Filter,
+		DescriptionAttribute: ldapGroupSetting.DescriptionAttribute,
+		Strategy:             ldapGroupSetting.Strategy,
+		Username:             testUsername,
+	}
+
+	var groups []ldapGroupTestResult
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetBody(payload).
+		SetResult(&groups).
+		Post(LdapGroupSettingTestEndpoint)
+	if err != nil {
+		return fmt.Errorf("LDAP group lookup test for ldap group setting '%s' with user '%s' failed: %s", ldapGroupSetting.Name, testUsername, err)
+	}
+
+	if len(groups) == 0 {
+		return fmt.Errorf("LDAP group lookup test for ldap group setting '%s' with user '%s' failed: no groups found", ldapGroupSetting.Name, testUsername)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package configuration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/configuration"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// ldapStandIn answers the LDAP test endpoints the way Artifactory does, for a directory containing a single
+// user "jdoe" under "ou=people" who is a member of the "developers" group under "ou=groups".
+func ldapStandIn(t *testing.T) util.ProvderMetadata {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatal(err)
+		}
+
+		switch r.URL.Path {
+		case "/" + configuration.LdapSettingTestEndpoint:
+			search := payload["search"].(map[string]interface{})
+			if search["searchBase"] != "ou=people" || payload["testUsername"] != "jdoe" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"User not found"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"info":"Successfully connected and authenticated the test user"}`))
+		case "/" + configuration.LdapGroupSettingTestEndpoint:
+			w.Header().Set("Content-Type", "application/json")
+			if payload["groupBaseDn"] != "ou=groups" || payload["username"] != "jdoe" {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"groupName":"developers"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return util.ProvderMetadata{Client: restyClient}
+}
+
+func TestVerifyLdapSetting(t *testing.T) {
+	m := ldapStandIn(t)
+
+	ldapSetting := configuration.LdapSetting{
+		Key:     "ldaptest",
+		LdapUrl: "ldap://ldaptestldap",
+		Search: configuration.LdapSearchType{
+			SearchFilter: "(uid={0})",
+			SearchBase:   "ou=people",
+		},
+	}
+
+	if err := configuration.VerifyLdapSetting(ldapSetting, "jdoe", m); err != nil {
+		t.Errorf("expected LDAP test to succeed, got: %s", err)
+	}
+
+	if err := configuration.VerifyLdapSetting(ldapSetting, "unknown", m); err == nil || !strings.Contains(err.Error(), "User not found") {
+		t.Errorf("expected LDAP test to fail for an unknown user, got: %v", err)
+	}
+
+	ldapSetting.Search.SearchBase = "ou=users"
+	if err := configuration.VerifyLdapSetting(ldapSetting, "jdoe", m); err == nil {
+		t.Error("expected LDAP test to fail with a wrong search base")
+	}
+}
+
+func TestVerifyLdapGroupSetting(t *testing.T) {
+	m := ldapStandIn(t)
+
+	ldapGroupSetting := configuration.LdapGroupSetting{
+		Name:                 "ldapgrouptest",
+		EnabledLdap:          "ldaptest",
+		GroupBaseDn:          "ou=groups",
+		GroupNameAttribute:   "cn",
+		GroupMemberAttribute: "uniqueMember",
+		Filter:               "(objectClass=groupOfNames)",
+		Strategy:             "STATIC",
+	}
+
+	if err := configuration.VerifyLdapGroupSetting(ldapGroupSetting, "jdoe", m); err != nil {
+		t.Errorf("expected LDAP group lookup to succeed, got: %s", err)
+	}
+
+	ldapGroupSetting.GroupBaseDn = "ou=teams"
+	if err := configuration.VerifyLdapGroupSetting(ldapGroupSetting, "jdoe", m); err == nil || !strings.Contains(err.Error(), "no groups found") {
+		t.Errorf("expected LDAP group lookup to fail with a wrong group base DN, got: %v", err)
+	}
+}
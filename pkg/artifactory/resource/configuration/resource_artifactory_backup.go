@@ -3,6 +3,7 @@ package configuration
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -172,7 +173,13 @@ func ResourceArtifactoryBackup() *schema.Resource {
 
 		// we should only have one backup config resource, using same id
 		d.SetId(unpackedBackup.Key)
-		return resourceBackupRead(ctx, d, m)
+
+		exportDiags := exportBackupOnApply(ctx, d, unpackedBackup, m)
+		if exportDiags.HasError() {
+			return exportDiags
+		}
+
+		return append(exportDiags, resourceBackupRead(ctx, d, m)...)
 	}
 
 	var resourceBackupDelete = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -205,7 +212,13 @@ backups:
 			},
 		},
 
-		Schema:      backupSchema,
-		Description: "Provides an Artifactory backup config resource. This resource configuration corresponds to backup config block in system configuration XML (REST endpoint: artifactory/api/system/configuration). Manages the automatic and periodic backups of the entire Artifactory instance",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema:        util.MergeMaps(backupSchema, backupExportSchema),
+		CustomizeDiff: backupExportDiff,
+		Description:   "Provides an Artifactory backup config resource. This resource configuration corresponds to backup config block in system configuration XML (REST endpoint: artifactory/api/system/configuration). Manages the automatic and periodic backups of the entire Artifactory instance",
 	}
 }
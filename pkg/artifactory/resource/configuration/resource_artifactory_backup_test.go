@@ -93,6 +93,60 @@ resource "artifactory_backup" "{{ .resourceName }}" {
 	})
 }
 
+func TestAccBackup_exportOnApply(t *testing.T) {
+	_, fqrn, resourceName := test.MkNames("backup-", "artifactory_backup")
+
+	const BackupTemplate = `
+resource "artifactory_backup" "{{ .resourceName }}" {
+    key             = "{{ .resourceName }}"
+    enabled         = false
+    cron_exp        = "0 0 2 ? * MON-SAT *"
+    export_on_apply = true
+    export_path     = "{{ .exportPath }}"
+}`
+
+	testData := map[string]string{
+		"resourceName": resourceName,
+		"exportPath":   "/tmp/" + resourceName,
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccBackupDestroy(resourceName),
+
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(fqrn, BackupTemplate, testData),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "last_export_status", configuration.BackupExportStatusSucceeded),
+					resource.TestCheckResourceAttrSet(fqrn, "last_export_time"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBackup_exportOnApplyRequiresExportPath(t *testing.T) {
+	config := `
+		resource "artifactory_backup" "missing-export-path" {
+		  key             = "missing-export-path"
+		  cron_exp        = "0 0 12 * * ? *"
+		  export_on_apply = true
+		}
+	`
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("export_path must be set when export_on_apply is enabled"),
+			},
+		},
+	})
+}
+
 func TestAccBackup_importNotFound(t *testing.T) {
 	config := `
 		resource "artifactory_backup" "not-exist-test" {
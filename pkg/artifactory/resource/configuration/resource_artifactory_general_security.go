@@ -2,11 +2,13 @@ package configuration
 
 import (
 	"context"
+	"encoding/xml"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
 	"gopkg.in/yaml.v3"
 )
 
@@ -14,8 +16,18 @@ type GeneralSecurity struct {
 	GeneralSettings `yaml:"security" json:"security"`
 }
 
+type XmlGeneralSecurityConfig struct {
+	XMLName  xml.Name        `xml:"config"`
+	Security GeneralSettings `xml:"security"`
+}
+
 type GeneralSettings struct {
-	AnonAccessEnabled bool `yaml:"anonAccessEnabled" json:"anonAccessEnabled"`
+	AnonAccessEnabled    bool                  `xml:"anonAccessEnabled" yaml:"anonAccessEnabled" json:"anonAccessEnabled"`
+	AccessClientSettings *AccessClientSettings `xml:"accessClientSettings" yaml:"accessClientSettings,omitempty" json:"-"`
+}
+
+type AccessClientSettings struct {
+	UserTokenMaxExpiresInMinutes int `xml:"userTokenMaxExpiresInMinutes" yaml:"userTokenMaxExpiresInMinutes"`
 }
 
 func ResourceArtifactoryGeneralSecurity() *schema.Resource {
@@ -35,32 +47,26 @@ func ResourceArtifactoryGeneralSecurity() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"user_token_max_expires_in_minutes": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validator.IntAtLeast(0),
+				Description: "Expiry, in minutes, of the access tokens the users create for themselves in the UI. " +
+					"The setting of the instance is left unchanged when this attribute is not set, and when the resource is destroyed.",
+			},
 		},
 	}
 }
 
 func resourceGeneralSecurityRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(util.ProvderMetadata).Client
-
-	generalSettings := GeneralSettings{}
-
-	_, err := c.R().SetResult(&generalSettings).Get("artifactory/api/securityconfig")
+	config := XmlGeneralSecurityConfig{}
+	_, err := m.(util.ProvderMetadata).Client.R().SetResult(&config).Get("artifactory/api/system/configuration")
 	if err != nil {
-		return diag.Errorf("failed to retrieve data from <base_url>/artifactory/api/securityconfig during Read")
-	}
-
-	s := GeneralSecurity{GeneralSettings: generalSettings}
-	packDiag := packGeneralSecurity(&s, d)
-
-	if packDiag != nil {
-		return packDiag
+		return diag.Errorf("failed to retrieve data from API: /artifactory/api/system/configuration during Read")
 	}
 
-	return diag.Diagnostics{{
-		Severity: diag.Warning,
-		Summary:  "Usage of Undocumented Artifactory API Endpoints",
-		Detail:   "The artifactory_general_security resource uses endpoints that are undocumented and may not work with SaaS environments, or may change without notice.",
-	}}
+	return packGeneralSecurity(&GeneralSecurity{GeneralSettings: config.Security}, d)
 }
 
 func resourceGeneralSecurityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -82,12 +88,16 @@ func resourceGeneralSecurityUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGeneralSecurityDelete(_ context.Context, _ *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var content = `
-security:
-  anonAccessEnabled: false
-`
+	content, err := yaml.Marshal(&GeneralSecurity{
+		GeneralSettings: GeneralSettings{
+			AnonAccessEnabled: false,
+		},
+	})
+	if err != nil {
+		return diag.Errorf("failed to marshal general security settings during Delete")
+	}
 
-	err := SendConfigurationPatch([]byte(content), m)
+	err = SendConfigurationPatch(content, m)
 	if err != nil {
 		return diag.Errorf("failed to send PATCH request to Artifactory during Delete")
 	}
@@ -103,16 +113,29 @@ func unpackGeneralSecurity(s *schema.ResourceData) *GeneralSecurity {
 		AnonAccessEnabled: d.GetBool("enable_anonymous_access", false),
 	}
 
+	// the expiry is only sent when it is configured, so the setting of the instance isn't overwritten otherwise
+	if !s.GetRawConfig().GetAttr("user_token_max_expires_in_minutes").IsNull() {
+		settings.AccessClientSettings = &AccessClientSettings{
+			UserTokenMaxExpiresInMinutes: d.GetInt("user_token_max_expires_in_minutes", false),
+		}
+	}
+
 	security.GeneralSettings = settings
 	return &security
 }
 
 func packGeneralSecurity(s *GeneralSecurity, d *schema.ResourceData) diag.Diagnostics {
-	setValue := util.MkLens(d)
-
-	errors := setValue("enable_anonymous_access", s.GeneralSettings.AnonAccessEnabled)
+	var errors []error
+	if err := d.Set("enable_anonymous_access", s.GeneralSettings.AnonAccessEnabled); err != nil {
+		errors = append(errors, err)
+	}
+	if s.GeneralSettings.AccessClientSettings != nil {
+		if err := d.Set("user_token_max_expires_in_minutes", s.GeneralSettings.AccessClientSettings.UserTokenMaxExpiresInMinutes); err != nil {
+			errors = append(errors, err)
+		}
+	}
 
-	if errors != nil && len(errors) > 0 {
+	if len(errors) > 0 {
 		return diag.Errorf("failed to pack general security settings %q", errors)
 	}
 
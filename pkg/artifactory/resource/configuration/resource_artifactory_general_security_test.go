@@ -9,11 +9,13 @@ import (
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/configuration"
 	"github.com/jfrog/terraform-provider-shared/util"
+	"gopkg.in/yaml.v3"
 )
 
 const GeneralSecurityTemplateFull = `
 resource "artifactory_general_security" "security" {
-	enable_anonymous_access = true
+	enable_anonymous_access           = true
+	user_token_max_expires_in_minutes = 120
 }`
 
 func TestAccGeneralSecurity_full(t *testing.T) {
@@ -28,6 +30,7 @@ func TestAccGeneralSecurity_full(t *testing.T) {
 				Config: GeneralSecurityTemplateFull,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(fqrn, "enable_anonymous_access", "true"),
+					resource.TestCheckResourceAttr(fqrn, "user_token_max_expires_in_minutes", "120"),
 				),
 			},
 			{
@@ -60,3 +63,16 @@ func testAccGeneralSecurityDestroy(id string) func(*terraform.State) error {
 		return nil
 	}
 }
+
+func TestGeneralSecurityPatchWithoutAccessClientSettings(t *testing.T) {
+	content, err := yaml.Marshal(&configuration.GeneralSecurity{
+		GeneralSettings: configuration.GeneralSettings{AnonAccessEnabled: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "security:\n    anonAccessEnabled: true\n"; string(content) != expected {
+		t.Fatalf("expected: %q\n\ngot: %q", expected, string(content))
+	}
+}
@@ -131,6 +131,14 @@ Hierarchy: The user's DN is indicative of the groups the user belongs to by usin
 	var resourceLdapGroupSettingsUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 		unpackedLdapGroupSetting := unpackLdapGroupSetting(d)
 
+		var diags diag.Diagnostics
+		if testUsername, ok := d.GetOk("test_username"); ok {
+			diags = ldapTestEndpointWarning("artifactory_ldap_group_setting")
+			if err := VerifyLdapGroupSetting(unpackedLdapGroupSetting, testUsername.(string), m); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+
 		/* EXPLANATION FOR BELOW CONSTRUCTION USAGE.
 		There is a difference in xml structure usage between GET and PATCH calls of API: /artifactory/api/system/configuration.
 		GET call structure has "security -> ldapGroupSettings -> ldapGroupSetting -> Array of ldapGroupSetting config blocks".
@@ -154,7 +162,7 @@ Hierarchy: The user's DN is indicative of the groups the user belongs to by usin
 
 		// we should only have one ldap group setting resource, using same id
 		d.SetId(unpackedLdapGroupSetting.Name)
-		return resourceLdapGroupSettingsRead(ctx, d, m)
+		return append(diags, resourceLdapGroupSettingsRead(ctx, d, m)...)
 	}
 
 	var resourceLdapGroupSettingsDelete = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -220,7 +228,7 @@ security:
 			},
 		},
 
-		Schema:      ldapGroupSettingsSchema,
+		Schema:      util.MergeMaps(ldapGroupSettingsSchema, testUsernameSchema),
 		Description: "Provides an Artifactory ldap group setting resource. This resource configuration corresponds to ldapGroupSettings config block in system configuration XML (REST endpoint: artifactory/api/system/configuration).",
 	}
 }
@@ -182,6 +182,14 @@ func ResourceArtifactoryLdapSetting() *schema.Resource {
 	var resourceLdapSettingsUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 		unpackedLdapSetting := unpackLdapSetting(d)
 
+		var diags diag.Diagnostics
+		if testUsername, ok := d.GetOk("test_username"); ok {
+			diags = ldapTestEndpointWarning("artifactory_ldap_setting")
+			if err := VerifyLdapSetting(unpackedLdapSetting, testUsername.(string), m); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+
 		/* EXPLANATION FOR BELOW CONSTRUCTION USAGE.
 		There is a difference in xml structure usage between GET and PATCH calls of API: /artifactory/api/system/configuration.
 		GET call structure has "security -> ldapSettings -> ldapSetting -> Array of ldapSetting config blocks".
@@ -205,7 +213,7 @@ func ResourceArtifactoryLdapSetting() *schema.Resource {
 
 		// we should only have one ldap setting resource, using same id
 		d.SetId(unpackedLdapSetting.Key)
-		return resourceLdapSettingsRead(ctx, d, m)
+		return append(diags, resourceLdapSettingsRead(ctx, d, m)...)
 	}
 
 	var resourceLdapSettingsDelete = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -271,7 +279,7 @@ security:
 			},
 		},
 
-		Schema:      ldapSettingsSchema,
+		Schema:      util.MergeMaps(ldapSettingsSchema, testUsernameSchema),
 		Description: "Provides an Artifactory ldap setting resource. This resource configuration corresponds to ldapSettings config block in system configuration XML (REST endpoint: artifactory/api/system/configuration).",
 	}
 }
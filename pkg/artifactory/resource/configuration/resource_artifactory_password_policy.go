@@ -0,0 +1,199 @@
+package configuration
+
+import (
+	"context"
+	"encoding/xml"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+	"gopkg.in/yaml.v3"
+)
+
+type PasswordExpirationPolicy struct {
+	Enabled        bool `xml:"enabled" yaml:"enabled"`
+	PasswordMaxAge int  `xml:"passwordMaxAge" yaml:"passwordMaxAge"`
+	NotifyByEmail  bool `xml:"notifyByEmail" yaml:"notifyByEmail"`
+}
+
+type PasswordResetPolicy struct {
+	Enabled               bool `xml:"enabled" yaml:"enabled"`
+	MaxAttemptsPerAddress int  `xml:"maxAttemptsPerAddress" yaml:"maxAttemptsPerAddress"`
+	TimeToBlockInMinutes  int  `xml:"timeToBlockInMinutes" yaml:"timeToBlockInMinutes"`
+}
+
+type PasswordSettings struct {
+	EncryptionPolicy string                   `xml:"encryptionPolicy" yaml:"encryptionPolicy"`
+	ExpirationPolicy PasswordExpirationPolicy `xml:"expirationPolicy" yaml:"expirationPolicy"`
+	ResetPolicy      PasswordResetPolicy      `xml:"resetPolicy" yaml:"resetPolicy"`
+}
+
+type SecurityPasswordSettings struct {
+	PasswordSettings PasswordSettings `xml:"passwordSettings" yaml:"passwordSettings"`
+}
+
+type XmlPasswordPolicyConfig struct {
+	XMLName  xml.Name                 `xml:"config" yaml:"-"`
+	Security SecurityPasswordSettings `xml:"security" yaml:"security"`
+}
+
+// defaultPasswordSettings are the password settings of a new Artifactory instance, restored when the resource is destroyed
+var defaultPasswordSettings = PasswordSettings{
+	EncryptionPolicy: "SUPPORTED",
+	ExpirationPolicy: PasswordExpirationPolicy{
+		Enabled:        false,
+		PasswordMaxAge: 60,
+		NotifyByEmail:  true,
+	},
+	ResetPolicy: PasswordResetPolicy{
+		Enabled:               true,
+		MaxAttemptsPerAddress: 3,
+		TimeToBlockInMinutes:  60,
+	},
+}
+
+func ResourceArtifactoryPasswordPolicy() *schema.Resource {
+	return &schema.Resource{
+		UpdateContext: resourcePasswordPolicyUpdate,
+		CreateContext: resourcePasswordPolicyUpdate,
+		DeleteContext: resourcePasswordPolicyDelete,
+		ReadContext:   resourcePasswordPolicyRead,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"encryption_policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          defaultPasswordSettings.EncryptionPolicy,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"REQUIRED", "SUPPORTED", "UNSUPPORTED"}, false)),
+				Description:      "Whether the users must use an encrypted password, or an API key or access token, with the REST API and clients. One of `REQUIRED`, `SUPPORTED` and `UNSUPPORTED`. Default is `SUPPORTED`.",
+			},
+			"expiration_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     defaultPasswordSettings.ExpirationPolicy.Enabled,
+				Description: "Force the users to change their password when it expires. Default is `false`.",
+			},
+			"password_max_age": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          defaultPasswordSettings.ExpirationPolicy.PasswordMaxAge,
+				ValidateDiagFunc: validator.IntAtLeast(1),
+				Description:      "Number of days after which the password expires. Default is 60.",
+			},
+			"notify_by_email": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     defaultPasswordSettings.ExpirationPolicy.NotifyByEmail,
+				Description: "Notify the users by email before their password expires. Default is `true`.",
+			},
+			"reset_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     defaultPasswordSettings.ResetPolicy.Enabled,
+				Description: "Limit the number of password reset requests sent from an IP address. Default is `true`.",
+			},
+			"reset_max_attempts_per_address": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          defaultPasswordSettings.ResetPolicy.MaxAttemptsPerAddress,
+				ValidateDiagFunc: validator.IntAtLeast(1),
+				Description:      "Maximum number of password reset requests from an IP address, before it's blocked. Default is 3.",
+			},
+			"reset_time_to_block_in_minutes": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          defaultPasswordSettings.ResetPolicy.TimeToBlockInMinutes,
+				ValidateDiagFunc: validator.IntAtLeast(1),
+				Description:      "Time, in minutes, an IP address is blocked after too many password reset requests. Default is 60.",
+			},
+		},
+		Description: "Provides the password policy of Artifactory: password encryption, expiration and reset. Only a single `artifactory_password_policy` resource is meant to be defined.",
+	}
+}
+
+func resourcePasswordPolicyRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := XmlPasswordPolicyConfig{}
+	_, err := m.(util.ProvderMetadata).Client.R().SetResult(&config).Get("artifactory/api/system/configuration")
+	if err != nil {
+		return diag.Errorf("failed to retrieve data from API: /artifactory/api/system/configuration during Read")
+	}
+
+	return packPasswordPolicy(&config.Security.PasswordSettings, d)
+}
+
+func resourcePasswordPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	content, err := yaml.Marshal(&XmlPasswordPolicyConfig{
+		Security: SecurityPasswordSettings{PasswordSettings: unpackPasswordPolicy(d)},
+	})
+	if err != nil {
+		return diag.Errorf("failed to marshal password policy during Update")
+	}
+
+	err = SendConfigurationPatch(content, m)
+	if err != nil {
+		return diag.Errorf("failed to send PATCH request to Artifactory during Update")
+	}
+
+	// we should only have one password policy resource, using same id
+	d.SetId("password_policy")
+	return resourcePasswordPolicyRead(ctx, d, m)
+}
+
+func resourcePasswordPolicyDelete(_ context.Context, _ *schema.ResourceData, m interface{}) diag.Diagnostics {
+	content, err := yaml.Marshal(&XmlPasswordPolicyConfig{
+		Security: SecurityPasswordSettings{PasswordSettings: defaultPasswordSettings},
+	})
+	if err != nil {
+		return diag.Errorf("failed to marshal password policy during Delete")
+	}
+
+	err = SendConfigurationPatch(content, m)
+	if err != nil {
+		return diag.Errorf("failed to send PATCH request to Artifactory during Delete")
+	}
+
+	return nil
+}
+
+func unpackPasswordPolicy(s *schema.ResourceData) PasswordSettings {
+	d := &util.ResourceData{ResourceData: s}
+
+	return PasswordSettings{
+		EncryptionPolicy: d.GetString("encryption_policy", false),
+		ExpirationPolicy: PasswordExpirationPolicy{
+			Enabled:        d.GetBool("expiration_enabled", false),
+			PasswordMaxAge: d.GetInt("password_max_age", false),
+			NotifyByEmail:  d.GetBool("notify_by_email", false),
+		},
+		ResetPolicy: PasswordResetPolicy{
+			Enabled:               d.GetBool("reset_enabled", false),
+			MaxAttemptsPerAddress: d.GetInt("reset_max_attempts_per_address", false),
+			TimeToBlockInMinutes:  d.GetInt("reset_time_to_block_in_minutes", false),
+		},
+	}
+}
+
+func packPasswordPolicy(s *PasswordSettings, d *schema.ResourceData) diag.Diagnostics {
+	setValue := util.MkLens(d)
+
+	setValue("encryption_policy", s.EncryptionPolicy)
+	setValue("expiration_enabled", s.ExpirationPolicy.Enabled)
+	setValue("password_max_age", s.ExpirationPolicy.PasswordMaxAge)
+	setValue("notify_by_email", s.ExpirationPolicy.NotifyByEmail)
+	setValue("reset_enabled", s.ResetPolicy.Enabled)
+	setValue("reset_max_attempts_per_address", s.ResetPolicy.MaxAttemptsPerAddress)
+	errors := setValue("reset_time_to_block_in_minutes", s.ResetPolicy.TimeToBlockInMinutes)
+
+	if errors != nil && len(errors) > 0 {
+		return diag.Errorf("failed to pack password policy %q", errors)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package configuration_test
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/configuration"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const PasswordPolicyTemplateFull = `
+resource "artifactory_password_policy" "policy" {
+	encryption_policy              = "REQUIRED"
+	expiration_enabled             = true
+	password_max_age               = 90
+	notify_by_email                = false
+	reset_enabled                  = true
+	reset_max_attempts_per_address = 5
+	reset_time_to_block_in_minutes = 30
+}`
+
+func TestAccPasswordPolicy_full(t *testing.T) {
+	fqrn := "artifactory_password_policy.policy"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccPasswordPolicyDestroy(fqrn),
+
+		Steps: []resource.TestStep{
+			{
+				Config: PasswordPolicyTemplateFull,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "encryption_policy", "REQUIRED"),
+					resource.TestCheckResourceAttr(fqrn, "expiration_enabled", "true"),
+					resource.TestCheckResourceAttr(fqrn, "password_max_age", "90"),
+					resource.TestCheckResourceAttr(fqrn, "notify_by_email", "false"),
+					resource.TestCheckResourceAttr(fqrn, "reset_enabled", "true"),
+					resource.TestCheckResourceAttr(fqrn, "reset_max_attempts_per_address", "5"),
+					resource.TestCheckResourceAttr(fqrn, "reset_time_to_block_in_minutes", "30"),
+				),
+			},
+			{
+				ResourceName:      fqrn,
+				ImportState:       true,
+				ImportStateId:     "password_policy",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestPasswordPolicyConfigUnmarshal(t *testing.T) {
+	const config = `
+<config xmlns="http://artifactory.jfrog.org/xsd/3.1.15">
+    <security>
+        <anonAccessEnabled>false</anonAccessEnabled>
+        <passwordSettings>
+            <encryptionPolicy>REQUIRED</encryptionPolicy>
+            <expirationPolicy>
+                <enabled>true</enabled>
+                <passwordMaxAge>90</passwordMaxAge>
+                <notifyByEmail>false</notifyByEmail>
+            </expirationPolicy>
+            <resetPolicy>
+                <enabled>true</enabled>
+                <maxAttemptsPerAddress>5</maxAttemptsPerAddress>
+                <timeToBlockInMinutes>30</timeToBlockInMinutes>
+            </resetPolicy>
+        </passwordSettings>
+        <userLockPolicy>
+            <enabled>true</enabled>
+            <loginAttempts>3</loginAttempts>
+        </userLockPolicy>
+        <accessClientSettings>
+            <userTokenMaxExpiresInMinutes>120</userTokenMaxExpiresInMinutes>
+        </accessClientSettings>
+    </security>
+</config>`
+
+	passwordPolicy := configuration.XmlPasswordPolicyConfig{}
+	if err := xml.Unmarshal([]byte(config), &passwordPolicy); err != nil {
+		t.Fatal(err)
+	}
+	expected := configuration.PasswordSettings{
+		EncryptionPolicy: "REQUIRED",
+		ExpirationPolicy: configuration.PasswordExpirationPolicy{Enabled: true, PasswordMaxAge: 90, NotifyByEmail: false},
+		ResetPolicy:      configuration.PasswordResetPolicy{Enabled: true, MaxAttemptsPerAddress: 5, TimeToBlockInMinutes: 30},
+	}
+	if !reflect.DeepEqual(expected, passwordPolicy.Security.PasswordSettings) {
+		t.Fatalf("expected: %v\n\ngot: %v", expected, passwordPolicy.Security.PasswordSettings)
+	}
+
+	userLockPolicy := configuration.XmlUserLockPolicyConfig{}
+	if err := xml.Unmarshal([]byte(config), &userLockPolicy); err != nil {
+		t.Fatal(err)
+	}
+	if userLockPolicy.Security.UserLockPolicy != (configuration.UserLockPolicy{Enabled: true, LoginAttempts: 3}) {
+		t.Fatalf("unexpected user lock policy %v", userLockPolicy.Security.UserLockPolicy)
+	}
+
+	generalSecurity := configuration.XmlGeneralSecurityConfig{}
+	if err := xml.Unmarshal([]byte(config), &generalSecurity); err != nil {
+		t.Fatal(err)
+	}
+	if generalSecurity.Security.AccessClientSettings == nil || generalSecurity.Security.AccessClientSettings.UserTokenMaxExpiresInMinutes != 120 {
+		t.Fatalf("unexpected access client settings %v", generalSecurity.Security.AccessClientSettings)
+	}
+}
+
+func testAccPasswordPolicyDestroy(id string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		client := acctest.Provider.Meta().(util.ProvderMetadata).Client
+
+		_, ok := s.RootModule().Resources[id]
+		if !ok {
+			return fmt.Errorf("error: resource id [%s] not found", id)
+		}
+
+		config := configuration.XmlPasswordPolicyConfig{}
+		_, err := client.R().SetResult(&config).Get("artifactory/api/system/configuration")
+		if err != nil {
+			return fmt.Errorf("error: failed to retrieve data from <base_url>/artifactory/api/system/configuration during Read")
+		}
+		if config.Security.PasswordSettings.EncryptionPolicy != "SUPPORTED" || config.Security.PasswordSettings.ExpirationPolicy.Enabled {
+			return fmt.Errorf("error: password policy was not restored to the defaults")
+		}
+
+		return nil
+	}
+}
@@ -2,158 +2,331 @@ package configuration
 
 import (
 	"context"
+	"encoding/xml"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/jfrog/terraform-provider-shared/util"
 	"gopkg.in/yaml.v3"
 )
 
-type SamlSecurity struct {
-	Saml SamlSettingsWrapper `yaml:"security"`
+// DefaultSamlSettingName is the name given to the SAML provider managed by the resource before it supported multiple providers
+const DefaultSamlSettingName = "default"
+
+type SamlGroupMapping struct {
+	IdpGroup         string `xml:"idpGroup"`
+	ArtifactoryGroup string `xml:"artifactoryGroup"`
+}
+
+type SamlSetting struct {
+	Name                      string             `xml:"name" yaml:"-"`
+	EnableIntegration         bool               `xml:"enableIntegration" yaml:"enableIntegration"`
+	Certificate               string             `xml:"certificate" yaml:"certificate"`
+	EmailAttribute            string             `xml:"emailAttribute" yaml:"emailAttribute"`
+	GroupAttribute            string             `xml:"groupAttribute" yaml:"groupAttribute"`
+	LoginUrl                  string             `xml:"loginUrl" yaml:"loginUrl"`
+	LogoutUrl                 string             `xml:"logoutUrl" yaml:"logoutUrl"`
+	NoAutoUserCreation        bool               `xml:"noAutoUserCreation" yaml:"noAutoUserCreation"`
+	ServiceProviderName       string             `xml:"serviceProviderName" yaml:"serviceProviderName"`
+	AllowUserToAccessProfile  bool               `xml:"allowUserToAccessProfile" yaml:"allowUserToAccessProfile"`
+	AutoRedirect              bool               `xml:"autoRedirect" yaml:"autoRedirect"`
+	SyncGroups                bool               `xml:"syncGroups" yaml:"syncGroups"`
+	VerifyAudienceRestriction bool               `xml:"verifyAudienceRestriction" yaml:"verifyAudienceRestriction"`
+	UseEncryptedAssertion     bool               `xml:"useEncryptedAssertion" yaml:"useEncryptedAssertion"`
+	GroupMappings             []SamlGroupMapping `xml:"groupMappings>groupMapping" yaml:"-"`
 }
 
-type SamlSettingsWrapper struct {
-	Settings SamlSettings `yaml:"samlSettings"`
+func (s SamlSetting) Id() string {
+	return s.Name
 }
 
 type SamlSettings struct {
-	EnableIntegration         bool   `yaml:"enableIntegration" json:"enableIntegration"`
-	Certificate               string `yaml:"certificate" json:"certificate"`
-	EmailAttribute            string `yaml:"emailAttribute" json:"emailAttribute"`
-	GroupAttribute            string `yaml:"groupAttribute" json:"groupAttribute"`
-	LoginUrl                  string `yaml:"loginUrl" json:"loginUrl"`
-	LogoutUrl                 string `yaml:"logoutUrl" json:"logoutUrl"`
-	NoAutoUserCreation        bool   `yaml:"noAutoUserCreation" json:"noAutoUserCreation"`
-	ServiceProviderName       string `yaml:"serviceProviderName" json:"serviceProviderName"`
-	AllowUserToAccessProfile  bool   `yaml:"allowUserToAccessProfile" json:"allowUserToAccessProfile"`
-	AutoRedirect              bool   `yaml:"autoRedirect" json:"autoRedirect"`
-	SyncGroups                bool   `yaml:"syncGroups" json:"syncGroups"`
-	VerifyAudienceRestriction bool   `yaml:"verifyAudienceRestriction" json:"verifyAudienceRestriction"`
-	UseEncryptedAssertion     bool   `yaml:"useEncryptedAssertion" json:"useEncryptedAssertion"`
+	SamlSettingArr []SamlSetting `xml:"samlSetting"`
+}
+
+type SecuritySamlSettings struct {
+	SamlSettings SamlSettings `xml:"samlSettings"`
+}
+
+type XmlSamlConfig struct {
+	XMLName  xml.Name             `xml:"config"`
+	Security SecuritySamlSettings `xml:"security"`
+}
+
+// samlSettingPatch is the PATCH form of a SAML provider. Group mappings are keyed by IdP group, and a nil
+// Artifactory group removes the mapping.
+type samlSettingPatch struct {
+	SamlSetting   `yaml:",inline"`
+	GroupMappings map[string]*string `yaml:"groupMappings,omitempty"`
+}
+
+var samlSettingsSchema = map[string]*schema.Schema{
+	"name": {
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ForceNew:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+		Description:      fmt.Sprintf(`The name of the SAML provider. A new SAML provider without name is named "%s".`, DefaultSamlSettingName),
+	},
+	"enable": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: `Enable SAML SSO.  Default value is "true".`,
+	},
+	"certificate": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "",
+		Description: `SAML certificate that contains the public key for the IdP service provider.  Used by Artifactory to verify sign-in requests. Default value is "".`,
+	},
+	"email_attribute": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "",
+		Description: `Name of the attribute in the SAML response from the IdP that contains the user's email. Default value is "".`,
+	},
+	"group_attribute": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "",
+		Description: `Name of the attribute in the SAML response from the IdP that contains the user's group memberships. Default value is "".`,
+	},
+	"login_url": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: `Service provider login url configured on the IdP.`,
+	},
+	"logout_url": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: `Service provider logout url, or where to redirect after user logs out.`,
+	},
+	"no_auto_user_creation": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: `When automatic user creation is off, authenticated users are not automatically created inside Artifactory. Instead, for every request from an SSO user, the user is temporarily associated with default groups (if such groups are defined), and the permissions for these groups apply. Without auto-user creation, you must manually create the user inside Artifactory to manage user permissions not attached to their default groups. Default value is "false".`,
+	},
+	"service_provider_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: `The SAML service provider name. This should be a URI that is also known as the entityID, providerID, or entity identity.`,
+	},
+	"allow_user_to_access_profile": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: `Allow persisted users to access their profile.  Default value is "true".`,
+	},
+	"auto_redirect": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: `Auto redirect to login through the IdP when clicking on Artifactory's login link.  Default value is "false".`,
+	},
+	"sync_groups": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: `Associate user with Artifactory groups based on the "group_attribute" provided in the SAML response from the identity provider.  Default value is "false".`,
+	},
+	"group_mapping": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"idp_group": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+					Description:      `Group name as sent by the IdP in the "group_attribute" of the SAML response.`,
+				},
+				"artifactory_group": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+					Description:      `Name of the Artifactory group the users of the IdP group are associated with.`,
+				},
+			},
+		},
+		Description: `Maps the groups sent by the IdP to Artifactory groups, when their names differ. Requires "sync_groups" and "group_attribute" to be set.`,
+	},
+	"verify_audience_restriction": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: `Enable "audience", or who the SAML assertion is intended for.  Ensures that the correct service provider intended for Artifactory is used on the IdP. Default value is "true".`,
+	},
+	"use_encrypted_assertion": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: `When set, an X.509 public certificate will be created by Artifactory. Download this certificate and upload it to your IDP and choose your own encryption algorithm. This process will let you encrypt the assertion section in your SAML response. Default value is "false".`,
+	},
+}
+
+var resourceSamlSettingsV0 = &schema.Resource{
+	Schema: func() map[string]*schema.Schema {
+		v0Schema := map[string]*schema.Schema{}
+		for key, value := range samlSettingsSchema {
+			if key != "name" && key != "group_mapping" {
+				v0Schema[key] = value
+			}
+		}
+		return v0Schema
+	}(),
 }
 
 func ResourceArtifactorySamlSettings() *schema.Resource {
 	return &schema.Resource{
+		SchemaVersion: 1,
 		UpdateContext: resourceSamlSettingsUpdate,
 		CreateContext: resourceSamlSettingsUpdate,
 		DeleteContext: resourceSamlSettingsDelete,
 		ReadContext:   resourceSamlSettingsRead,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceSamlSettingsImport,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"enable": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     true,
-				Description: `Enable SAML SSO.  Default value is "true".`,
-			},
-			"certificate": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: `SAML certificate that contains the public key for the IdP service provider.  Used by Artifactory to verify sign-in requests. Default value is "".`,
-			},
-			"email_attribute": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: `Name of the attribute in the SAML response from the IdP that contains the user's email. Default value is "".`,
-			},
-			"group_attribute": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: `Name of the attribute in the SAML response from the IdP that contains the user's group memberships. Default value is "".`,
-			},
-			"login_url": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: `Service provider login url configured on the IdP.`,
-			},
-			"logout_url": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: `Service provider logout url, or where to redirect after user logs out.`,
-			},
-			"no_auto_user_creation": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: `When automatic user creation is off, authenticated users are not automatically created inside Artifactory. Instead, for every request from an SSO user, the user is temporarily associated with default groups (if such groups are defined), and the permissions for these groups apply. Without auto-user creation, you must manually create the user inside Artifactory to manage user permissions not attached to their default groups. Default value is "false".`,
-			},
-			"service_provider_name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: `The SAML service provider name. This should be a URI that is also known as the entityID, providerID, or entity identity.`,
-			},
-			"allow_user_to_access_profile": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     true,
-				Description: `Allow persisted users to access their profile.  Default value is "true".`,
-			},
-			"auto_redirect": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: `Auto redirect to login through the IdP when clicking on Artifactory's login link.  Default value is "false".`,
-			},
-			"sync_groups": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: `Associate user with Artifactory groups based on the "group_attribute" provided in the SAML response from the identity provider.  Default value is "false".`,
-			},
-			"verify_audience_restriction": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     true,
-				Description: `Enable "audience", or who the SAML assertion is intended for.  Ensures that the correct service provider intended for Artifactory is used on the IdP. Default value is "true".`,
-			},
-			"use_encrypted_assertion": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: `When set, an X.509 public certificate will be created by Artifactory. Download this certificate and upload it to your IDP and choose your own encryption algorithm. This process will let you encrypt the assertion section in your SAML response. Default value is "false".`,
+		Schema: samlSettingsSchema,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceSamlSettingsV0.CoreConfigSchema().ImpliedType(),
+				Upgrade: ResourceSamlSettingsStateUpgradeV0,
+				Version: 0,
 			},
 		},
+
+		CustomizeDiff: customdiff.All(samlSettingNameDiff, verifySamlGroupMapping),
 	}
 }
 
-func resourceSamlSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(util.ProvderMetadata).Client
+// ResourceSamlSettingsStateUpgradeV0 converts the state of the singleton SAML settings, which used the fixed
+// `saml_settings` ID, into the state of the SAML provider it managed.
+func ResourceSamlSettingsStateUpgradeV0(_ context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	name, err := singletonSamlSettingName(rawState, m)
+	if err != nil {
+		return nil, err
+	}
 
-	samlSettings := SamlSettings{}
+	rawState["id"] = name
+	rawState["name"] = name
 
-	_, err := c.R().SetResult(&samlSettings).Get("artifactory/api/saml/config")
+	return rawState, nil
+}
+
+// singletonSamlSettingName returns the name of the SAML provider managed by the singleton SAML settings: the only
+// provider, or else the provider with the same service provider name and login URL. DefaultSamlSettingName is
+// returned when there is no provider, so the next refresh removes the SAML settings from the state.
+func singletonSamlSettingName(rawState map[string]interface{}, m interface{}) (string, error) {
+	samlConfigs := XmlSamlConfig{}
+	_, err := m.(util.ProvderMetadata).Client.R().SetResult(&samlConfigs).Get("artifactory/api/system/configuration")
 	if err != nil {
-		return diag.Errorf("failed to retrieve data from <base_url>/artifactory/api/saml/config during Read")
+		return "", fmt.Errorf("failed to retrieve the SAML providers to upgrade the state: %s", err)
+	}
+
+	samlSettings := samlConfigs.Security.SamlSettings.SamlSettingArr
+	switch len(samlSettings) {
+	case 0:
+		return DefaultSamlSettingName, nil
+	case 1:
+		return samlSettings[0].Name, nil
+	}
+
+	for _, samlSetting := range samlSettings {
+		if samlSetting.ServiceProviderName == rawState["service_provider_name"] && samlSetting.LoginUrl == rawState["login_url"] {
+			return samlSetting.Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("none of the %d SAML providers has the service_provider_name and login_url of the state. "+
+		"Remove the SAML settings from the state, and import the SAML provider by name", len(samlSettings))
+}
+
+// samlSettingNameDiff plans DefaultSamlSettingName as the name of a new SAML provider without name. The name isn't
+// a default value, so the name of the provider upgraded from the singleton SAML settings is kept.
+func samlSettingNameDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" && diff.GetRawConfig().GetAttr("name").IsNull() {
+		return diff.SetNew("name", DefaultSamlSettingName)
+	}
+
+	return nil
+}
+
+func verifySamlGroupMapping(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if mappings, ok := diff.GetOk("group_mapping"); ok && mappings.(*schema.Set).Len() > 0 {
+		if !diff.Get("sync_groups").(bool) || diff.Get("group_attribute").(string) == "" {
+			return fmt.Errorf("group_mapping requires sync_groups to be enabled and group_attribute to be set")
+		}
 	}
 
-	s := SamlSecurity{SamlSettingsWrapper{Settings: samlSettings}}
+	return nil
+}
+
+func resourceSamlSettingsImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	// imports of the singleton SAML settings used a fixed ID
+	if d.Id() == "saml_settings" {
+		d.SetId(DefaultSamlSettingName)
+	}
+	if err := d.Set("name", d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSamlSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	samlConfigs := XmlSamlConfig{}
+	_, err := m.(util.ProvderMetadata).Client.R().SetResult(&samlConfigs).Get("artifactory/api/system/configuration")
+	if err != nil {
+		return diag.Errorf("failed to retrieve data from API: /artifactory/api/system/configuration during Read")
+	}
 
-	packDiag := packSamlSecurity(ctx, &s, d)
-	if packDiag != nil {
-		return packDiag
+	matchedSamlSetting := FindConfigurationById[SamlSetting](samlConfigs.Security.SamlSettings.SamlSettingArr, d.Id())
+	if matchedSamlSetting == nil {
+		d.SetId("")
+		return nil
 	}
 
-	return diag.Diagnostics{{
-		Severity: diag.Warning,
-		Summary:  "Usage of Undocumented Artifactory API Endpoints",
-		Detail:   "The artifactory_saml_settings resource uses endpoints that are undocumented and may not work with SaaS environments, or may change without notice.",
-	}}
+	return packSamlSetting(ctx, matchedSamlSetting, d)
 }
 
 func resourceSamlSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	unpacked := unpackSamlSecurity(ctx, d)
-	content, err := yaml.Marshal(&unpacked)
+	unpackedSamlSetting := unpackSamlSetting(ctx, d)
 
+	patch := samlSettingPatch{
+		SamlSetting:   unpackedSamlSetting,
+		GroupMappings: map[string]*string{},
+	}
+	// mappings removed from the configuration are deleted by the PATCH, as it merges them with the existing ones
+	oldMappings, _ := d.GetChange("group_mapping")
+	for _, mapping := range oldMappings.(*schema.Set).List() {
+		patch.GroupMappings[mapping.(map[string]interface{})["idp_group"].(string)] = nil
+	}
+	for _, mapping := range unpackedSamlSetting.GroupMappings {
+		artifactoryGroup := mapping.ArtifactoryGroup
+		patch.GroupMappings[mapping.IdpGroup] = &artifactoryGroup
+	}
+
+	/* EXPLANATION FOR BELOW CONSTRUCTION USAGE.
+	There is a difference in xml structure usage between GET and PATCH calls of API: /artifactory/api/system/configuration.
+	GET call structure has "security -> samlSettings -> samlSetting -> Array of samlSetting config blocks".
+	PATCH call structure has "security -> samlSettings -> Name of saml setting that is being patch -> config block of the samlSetting being patched".
+	Since the Name is dynamic string, following nested map of string structs are constructed to match the usage of PATCH call.
+	*/
+	var constructBody = map[string]map[string]map[string]samlSettingPatch{}
+	constructBody["security"] = map[string]map[string]samlSettingPatch{}
+	constructBody["security"]["samlSettings"] = map[string]samlSettingPatch{}
+	constructBody["security"]["samlSettings"][unpackedSamlSetting.Name] = patch
+	content, err := yaml.Marshal(&constructBody)
 	if err != nil {
 		return diag.Errorf("failed to marshal saml settings during Update")
 	}
@@ -163,18 +336,25 @@ func resourceSamlSettingsUpdate(ctx context.Context, d *schema.ResourceData, m i
 		return diag.Errorf("failed to send PATCH request to Artifactory during Update")
 	}
 
-	// we should only have one saml settings resource, using same id
-	d.SetId("saml_settings")
+	d.SetId(unpackedSamlSetting.Name)
 	return resourceSamlSettingsRead(ctx, d, m)
 }
 
-func resourceSamlSettingsDelete(_ context.Context, _ *schema.ResourceData, m interface{}) diag.Diagnostics {
-	var content = `
-security:
-  samlSettings: ~
-`
+func resourceSamlSettingsDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var constructBody = map[string]map[string]map[string]string{
+		"security": {
+			"samlSettings": {
+				d.Id(): "~",
+			},
+		},
+	}
+
+	content, err := yaml.Marshal(&constructBody)
+	if err != nil {
+		return diag.Errorf("failed to marshal saml settings during Delete")
+	}
 
-	err := SendConfigurationPatch([]byte(content), m)
+	err = SendConfigurationPatch(content, m)
 	if err != nil {
 		return diag.Errorf("failed to send PATCH request to Artifactory during Delete")
 	}
@@ -182,11 +362,11 @@ security:
 	return nil
 }
 
-func unpackSamlSecurity(ctx context.Context, s *schema.ResourceData) *SamlSecurity {
+func unpackSamlSetting(ctx context.Context, s *schema.ResourceData) SamlSetting {
 	d := &util.ResourceData{ResourceData: s}
-	security := *new(SamlSecurity)
 
-	settings := SamlSettings{
+	setting := SamlSetting{
+		Name:                      d.GetString("name", false),
 		EnableIntegration:         d.GetBool("enable", false),
 		Certificate:               d.GetString("certificate", false),
 		EmailAttribute:            d.GetString("email_attribute", false),
@@ -203,27 +383,46 @@ func unpackSamlSecurity(ctx context.Context, s *schema.ResourceData) *SamlSecuri
 	}
 	tflog.Info(ctx, "unpacking no_auto_user_creation with inverted value from API because API changes its sematic.")
 
-	security.Saml.Settings = settings
-	return &security
+	if v, ok := s.GetOk("group_mapping"); ok {
+		for _, mapping := range v.(*schema.Set).List() {
+			m := mapping.(map[string]interface{})
+			setting.GroupMappings = append(setting.GroupMappings, SamlGroupMapping{
+				IdpGroup:         m["idp_group"].(string),
+				ArtifactoryGroup: m["artifactory_group"].(string),
+			})
+		}
+	}
+
+	return setting
 }
 
-func packSamlSecurity(ctx context.Context, s *SamlSecurity, d *schema.ResourceData) diag.Diagnostics {
+func packSamlSetting(ctx context.Context, s *SamlSetting, d *schema.ResourceData) diag.Diagnostics {
 	setValue := util.MkLens(d)
 
-	setValue("enable", s.Saml.Settings.EnableIntegration)
-	setValue("certificate", s.Saml.Settings.Certificate)
-	setValue("email_attribute", s.Saml.Settings.EmailAttribute)
-	setValue("group_attribute", s.Saml.Settings.GroupAttribute)
-	setValue("login_url", s.Saml.Settings.LoginUrl)
-	setValue("logout_url", s.Saml.Settings.LogoutUrl)
-	setValue("no_auto_user_creation", !s.Saml.Settings.NoAutoUserCreation)
+	setValue("name", s.Name)
+	setValue("enable", s.EnableIntegration)
+	setValue("certificate", s.Certificate)
+	setValue("email_attribute", s.EmailAttribute)
+	setValue("group_attribute", s.GroupAttribute)
+	setValue("login_url", s.LoginUrl)
+	setValue("logout_url", s.LogoutUrl)
+	setValue("no_auto_user_creation", !s.NoAutoUserCreation)
 	tflog.Info(ctx, "packing no_auto_user_creation with inverted value from API because API changes its sematic.")
-	setValue("service_provider_name", s.Saml.Settings.ServiceProviderName)
-	setValue("allow_user_to_access_profile", s.Saml.Settings.AllowUserToAccessProfile)
-	setValue("auto_redirect", s.Saml.Settings.AutoRedirect)
-	setValue("sync_groups", s.Saml.Settings.SyncGroups)
-	setValue("use_encrypted_assertion", s.Saml.Settings.UseEncryptedAssertion)
-	errors := setValue("verify_audience_restriction", s.Saml.Settings.VerifyAudienceRestriction)
+	setValue("service_provider_name", s.ServiceProviderName)
+	setValue("allow_user_to_access_profile", s.AllowUserToAccessProfile)
+	setValue("auto_redirect", s.AutoRedirect)
+	setValue("sync_groups", s.SyncGroups)
+	setValue("use_encrypted_assertion", s.UseEncryptedAssertion)
+
+	var groupMappings []interface{}
+	for _, mapping := range s.GroupMappings {
+		groupMappings = append(groupMappings, map[string]interface{}{
+			"idp_group":         mapping.IdpGroup,
+			"artifactory_group": mapping.ArtifactoryGroup,
+		})
+	}
+	setValue("group_mapping", groupMappings)
+	errors := setValue("verify_audience_restriction", s.VerifyAudienceRestriction)
 
 	if errors != nil && len(errors) > 0 {
 		return diag.Errorf("failed to pack saml settings %q", errors)
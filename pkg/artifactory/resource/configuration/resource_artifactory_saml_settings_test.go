@@ -1,18 +1,26 @@
 package configuration_test
 
 import (
+	"context"
+	"encoding/xml"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/configuration"
+	"github.com/jfrog/terraform-provider-shared/client"
 	"github.com/jfrog/terraform-provider-shared/util"
 )
 
 const SamlSettingsTemplateFull = `
 resource "artifactory_saml_settings" "saml" {
+	name                         = "okta"
 	enable 					     = true
 	certificate                  = "test-certificate"
 	email_attribute              = "email"
@@ -25,7 +33,12 @@ resource "artifactory_saml_settings" "saml" {
 	auto_redirect                = true
 	sync_groups                  = true
 	verify_audience_restriction  = true
-    use_encrypted_assertion      = false
+	use_encrypted_assertion      = false
+
+	group_mapping {
+		idp_group         = "okta-developers"
+		artifactory_group = "readers"
+	}
 }`
 
 func TestAccSamlSettings_full(t *testing.T) {
@@ -39,6 +52,8 @@ func TestAccSamlSettings_full(t *testing.T) {
 			{
 				Config: SamlSettingsTemplateFull,
 				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "id", "okta"),
+					resource.TestCheckResourceAttr(fqrn, "name", "okta"),
 					resource.TestCheckResourceAttr(fqrn, "enable", "true"),
 					resource.TestCheckResourceAttr(fqrn, "certificate", "test-certificate"),
 					resource.TestCheckResourceAttr(fqrn, "email_attribute", "email"),
@@ -52,6 +67,11 @@ func TestAccSamlSettings_full(t *testing.T) {
 					resource.TestCheckResourceAttr(fqrn, "sync_groups", "true"),
 					resource.TestCheckResourceAttr(fqrn, "verify_audience_restriction", "true"),
 					resource.TestCheckResourceAttr(fqrn, "use_encrypted_assertion", "false"),
+					resource.TestCheckResourceAttr(fqrn, "group_mapping.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(fqrn, "group_mapping.*", map[string]string{
+						"idp_group":         "okta-developers",
+						"artifactory_group": "readers",
+					}),
 				),
 			},
 			{
@@ -63,34 +83,127 @@ func TestAccSamlSettings_full(t *testing.T) {
 	})
 }
 
+func TestAccSamlSettings_group_mapping_requires_sync_groups(t *testing.T) {
+	const config = `
+resource "artifactory_saml_settings" "saml" {
+	name                  = "okta"
+	login_url             = "test-login-url"
+	logout_url            = "test-logout-url"
+	service_provider_name = "okta"
+	sync_groups           = false
+
+	group_mapping {
+		idp_group         = "okta-developers"
+		artifactory_group = "readers"
+	}
+}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("group_mapping requires sync_groups to be enabled and group_attribute to be set"),
+			},
+		},
+	})
+}
+
 func testAccSamlSettingsDestroy(id string) func(*terraform.State) error {
 	return func(s *terraform.State) error {
 		c := acctest.Provider.Meta().(util.ProvderMetadata).Client
 
-		_, ok := s.RootModule().Resources[id]
+		rs, ok := s.RootModule().Resources[id]
 		if !ok {
 			return fmt.Errorf("error: resource id [%s] not found", id)
 		}
-		samlSettings := configuration.SamlSettings{}
+		samlConfigs := &configuration.XmlSamlConfig{}
 
-		_, err := c.R().SetResult(&samlSettings).Get("artifactory/api/saml/config")
+		_, err := c.R().SetResult(&samlConfigs).Get("artifactory/api/system/configuration")
 		if err != nil {
-			return fmt.Errorf("error: failed to retrieve data from <base_url>/artifactory/api/saml/config during Read")
+			return fmt.Errorf("error: failed to retrieve data from <base_url>/artifactory/api/system/configuration during Read")
 		}
 
-		if samlSettings.AllowUserToAccessProfile != false {
-			return fmt.Errorf("error: SAML SSO setting, allow user to access profile, is still enabled")
-		}
-		if samlSettings.SyncGroups != false {
-			return fmt.Errorf("error: SAML SSO setting, sync groups, is still enabled")
-		}
-		if samlSettings.NoAutoUserCreation != false {
-			return fmt.Errorf("error: SAML SSO setting, no auto user creation, is still enabled")
-		}
-		if samlSettings.EnableIntegration != false {
-			return fmt.Errorf("error: SAML SSO integration is still enabled")
+		matchedSamlSetting := configuration.FindConfigurationById[configuration.SamlSetting](samlConfigs.Security.SamlSettings.SamlSettingArr, rs.Primary.ID)
+		if matchedSamlSetting != nil {
+			return fmt.Errorf("error: SAML provider %s still exists", rs.Primary.ID)
 		}
 
 		return nil
 	}
 }
+
+// samlStandIn answers the system configuration with the given SAML providers
+func samlStandIn(t *testing.T, samlSettings ...configuration.SamlSetting) util.ProvderMetadata {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		config := configuration.XmlSamlConfig{}
+		config.Security.SamlSettings.SamlSettingArr = samlSettings
+
+		w.Header().Set("Content-Type", "application/xml")
+		if err := xml.NewEncoder(w).Encode(config); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return util.ProvderMetadata{Client: restyClient}
+}
+
+// Unit tests for state migration func
+func TestSamlSettingsResourceStateUpgradeV0(t *testing.T) {
+	okta := configuration.SamlSetting{Name: "okta", ServiceProviderName: "okta", LoginUrl: "test-login-url"}
+	other := configuration.SamlSetting{Name: "other", ServiceProviderName: "other", LoginUrl: "other-login-url"}
+
+	testCases := []struct {
+		name         string
+		samlSettings []configuration.SamlSetting
+		expectedName string
+		errorRegex   string
+	}{
+		{name: "no provider", expectedName: configuration.DefaultSamlSettingName},
+		{name: "single provider", samlSettings: []configuration.SamlSetting{okta}, expectedName: "okta"},
+		{name: "matching provider", samlSettings: []configuration.SamlSetting{other, okta}, expectedName: "okta"},
+		{name: "no matching provider", samlSettings: []configuration.SamlSetting{other, other}, errorRegex: "none of the 2 SAML providers"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v0Data := map[string]interface{}{
+				"id":                    "saml_settings",
+				"enable":                true,
+				"login_url":             "test-login-url",
+				"logout_url":            "test-logout-url",
+				"service_provider_name": "okta",
+			}
+
+			actual, err := configuration.ResourceSamlSettingsStateUpgradeV0(context.Background(), v0Data, samlStandIn(t, tc.samlSettings...))
+			if tc.errorRegex != "" {
+				if err == nil || !regexp.MustCompile(tc.errorRegex).MatchString(err.Error()) {
+					t.Fatalf("expected error matching %q, got %v", tc.errorRegex, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error migrating state: %s", err)
+			}
+
+			v1Data := map[string]interface{}{
+				"id":                    tc.expectedName,
+				"name":                  tc.expectedName,
+				"enable":                true,
+				"login_url":             "test-login-url",
+				"logout_url":            "test-logout-url",
+				"service_provider_name": "okta",
+			}
+			if !reflect.DeepEqual(v1Data, actual) {
+				t.Fatalf("expected: %v\n\ngot: %v", v1Data, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package configuration
+
+import (
+	"context"
+	"encoding/xml"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+	"gopkg.in/yaml.v3"
+)
+
+type UserLockPolicy struct {
+	Enabled       bool `xml:"enabled" yaml:"enabled"`
+	LoginAttempts int  `xml:"loginAttempts" yaml:"loginAttempts"`
+}
+
+type SecurityUserLockPolicy struct {
+	UserLockPolicy UserLockPolicy `xml:"userLockPolicy" yaml:"userLockPolicy"`
+}
+
+type XmlUserLockPolicyConfig struct {
+	XMLName  xml.Name               `xml:"config" yaml:"-"`
+	Security SecurityUserLockPolicy `xml:"security" yaml:"security"`
+}
+
+// defaultUserLockPolicy is the user lock policy of a new Artifactory instance, restored when the resource is destroyed
+var defaultUserLockPolicy = UserLockPolicy{
+	Enabled:       false,
+	LoginAttempts: 5,
+}
+
+func ResourceArtifactoryUserLockPolicy() *schema.Resource {
+	return &schema.Resource{
+		UpdateContext: resourceUserLockPolicyUpdate,
+		CreateContext: resourceUserLockPolicyUpdate,
+		DeleteContext: resourceUserLockPolicyDelete,
+		ReadContext:   resourceUserLockPolicyRead,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     defaultUserLockPolicy.Enabled,
+				Description: "Lock the users after too many failed login attempts. Default is `false`.",
+			},
+			"login_attempts": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          defaultUserLockPolicy.LoginAttempts,
+				ValidateDiagFunc: validator.IntAtLeast(1),
+				Description:      "Number of failed login attempts after which the user is locked. Default is 5.",
+			},
+		},
+		Description: "Provides the user lock policy of Artifactory. Only a single `artifactory_user_lock_policy` resource is meant to be defined.",
+	}
+}
+
+func resourceUserLockPolicyRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := XmlUserLockPolicyConfig{}
+	_, err := m.(util.ProvderMetadata).Client.R().SetResult(&config).Get("artifactory/api/system/configuration")
+	if err != nil {
+		return diag.Errorf("failed to retrieve data from API: /artifactory/api/system/configuration during Read")
+	}
+
+	setValue := util.MkLens(d)
+	setValue("enabled", config.Security.UserLockPolicy.Enabled)
+	errors := setValue("login_attempts", config.Security.UserLockPolicy.LoginAttempts)
+
+	if errors != nil && len(errors) > 0 {
+		return diag.Errorf("failed to pack user lock policy %q", errors)
+	}
+
+	return nil
+}
+
+func resourceUserLockPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	content, err := yaml.Marshal(&XmlUserLockPolicyConfig{
+		Security: SecurityUserLockPolicy{
+			UserLockPolicy: UserLockPolicy{
+				Enabled:       d.Get("enabled").(bool),
+				LoginAttempts: d.Get("login_attempts").(int),
+			},
+		},
+	})
+	if err != nil {
+		return diag.Errorf("failed to marshal user lock policy during Update")
+	}
+
+	err = SendConfigurationPatch(content, m)
+	if err != nil {
+		return diag.Errorf("failed to send PATCH request to Artifactory during Update")
+	}
+
+	// we should only have one user lock policy resource, using same id
+	d.SetId("user_lock_policy")
+	return resourceUserLockPolicyRead(ctx, d, m)
+}
+
+func resourceUserLockPolicyDelete(_ context.Context, _ *schema.ResourceData, m interface{}) diag.Diagnostics {
+	content, err := yaml.Marshal(&XmlUserLockPolicyConfig{
+		Security: SecurityUserLockPolicy{UserLockPolicy: defaultUserLockPolicy},
+	})
+	if err != nil {
+		return diag.Errorf("failed to marshal user lock policy during Delete")
+	}
+
+	err = SendConfigurationPatch(content, m)
+	if err != nil {
+		return diag.Errorf("failed to send PATCH request to Artifactory during Delete")
+	}
+
+	return nil
+}
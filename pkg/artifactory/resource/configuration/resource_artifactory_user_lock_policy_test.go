@@ -0,0 +1,72 @@
+package configuration_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/configuration"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const UserLockPolicyTemplate = `
+resource "artifactory_user_lock_policy" "policy" {
+	enabled        = %t
+	login_attempts = %d
+}`
+
+func TestAccUserLockPolicy_full(t *testing.T) {
+	fqrn := "artifactory_user_lock_policy.policy"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccUserLockPolicyDestroy(fqrn),
+
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(UserLockPolicyTemplate, true, 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "enabled", "true"),
+					resource.TestCheckResourceAttr(fqrn, "login_attempts", "3"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(UserLockPolicyTemplate, true, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "enabled", "true"),
+					resource.TestCheckResourceAttr(fqrn, "login_attempts", "10"),
+				),
+			},
+			{
+				ResourceName:      fqrn,
+				ImportState:       true,
+				ImportStateId:     "user_lock_policy",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccUserLockPolicyDestroy(id string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		client := acctest.Provider.Meta().(util.ProvderMetadata).Client
+
+		_, ok := s.RootModule().Resources[id]
+		if !ok {
+			return fmt.Errorf("error: resource id [%s] not found", id)
+		}
+
+		config := configuration.XmlUserLockPolicyConfig{}
+		_, err := client.R().SetResult(&config).Get("artifactory/api/system/configuration")
+		if err != nil {
+			return fmt.Errorf("error: failed to retrieve data from <base_url>/artifactory/api/system/configuration during Read")
+		}
+		if config.Security.UserLockPolicy.Enabled {
+			return fmt.Errorf("error: user lock policy is still enabled")
+		}
+
+		return nil
+	}
+}
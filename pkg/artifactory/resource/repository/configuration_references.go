@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/configuration"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const (
+	keypairReferenceEndpoint     = "artifactory/api/security/keypair/{name}"
+	certificateReferenceEndpoint = "artifactory/api/system/security/certificates/"
+)
+
+// referencedConfiguration holds the parts of the system configuration which repositories can reference by name
+type referencedConfiguration struct {
+	configuration.Proxies
+	configuration.PropertySets
+	configuration.Layouts
+}
+
+type referencedCertificate struct {
+	CertificateAlias string `json:"certificateAlias"`
+}
+
+// configurationReference describes an attribute holding the name of a configuration object defined elsewhere in Artifactory
+type configurationReference struct {
+	attribute  string
+	objectType string
+	exists     func(name string, lookup *referenceLookup) (bool, error)
+}
+
+// referenceLookup fetches the referenced objects lazily, so only the endpoints needed by the changed attributes are called
+type referenceLookup struct {
+	m             interface{}
+	configuration *referencedConfiguration
+	certificates  []referencedCertificate
+}
+
+func (l *referenceLookup) getConfiguration() (*referencedConfiguration, error) {
+	if l.configuration == nil {
+		config := referencedConfiguration{}
+		_, err := l.m.(util.ProvderMetadata).Client.R().SetResult(&config).Get("artifactory/api/system/configuration")
+		if err != nil {
+			return nil, err
+		}
+		l.configuration = &config
+	}
+	return l.configuration, nil
+}
+
+func (l *referenceLookup) getCertificates() ([]referencedCertificate, error) {
+	if l.certificates == nil {
+		certificates := []referencedCertificate{}
+		_, err := l.m.(util.ProvderMetadata).Client.R().SetResult(&certificates).Get(certificateReferenceEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		l.certificates = certificates
+	}
+	return l.certificates, nil
+}
+
+var proxyExists = func(name string, lookup *referenceLookup) (bool, error) {
+	config, err := lookup.getConfiguration()
+	if err != nil {
+		return false, err
+	}
+	return configuration.FindConfigurationById[configuration.Proxy](config.Proxies.Proxies, name) != nil, nil
+}
+
+var propertySetExists = func(name string, lookup *referenceLookup) (bool, error) {
+	config, err := lookup.getConfiguration()
+	if err != nil {
+		return false, err
+	}
+	return configuration.FindConfigurationById[configuration.PropertySet](config.PropertySets.PropertySets, name) != nil, nil
+}
+
+var layoutExists = func(name string, lookup *referenceLookup) (bool, error) {
+	config, err := lookup.getConfiguration()
+	if err != nil {
+		return false, err
+	}
+	return configuration.FindConfigurationById[configuration.Layout](config.Layouts.Layouts, name) != nil, nil
+}
+
+var keypairExists = func(name string, lookup *referenceLookup) (bool, error) {
+	resp, err := lookup.m.(util.ProvderMetadata).Client.R().
+		SetPathParam("name", name).
+		Head(keypairReferenceEndpoint)
+	if err != nil {
+		if resp != nil && resp.StatusCode() == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+var certificateExists = func(name string, lookup *referenceLookup) (bool, error) {
+	certificates, err := lookup.getCertificates()
+	if err != nil {
+		return false, err
+	}
+	for _, certificate := range certificates {
+		if certificate.CertificateAlias == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+var configurationReferences = []configurationReference{
+	{attribute: "proxy", objectType: "proxy", exists: proxyExists},
+	{attribute: "property_sets", objectType: "property set", exists: propertySetExists},
+	{attribute: "repo_layout_ref", objectType: "repository layout", exists: layoutExists},
+	{attribute: "remote_repo_layout_ref", objectType: "repository layout", exists: layoutExists},
+	{attribute: "primary_keypair_ref", objectType: "keypair", exists: keypairExists},
+	{attribute: "secondary_keypair_ref", objectType: "keypair", exists: keypairExists},
+	{attribute: "client_tls_certificate", objectType: "certificate", exists: certificateExists},
+}
+
+// VerifyConfigurationReferences checks that the proxies, property sets, layouts, keypairs and certificates
+// referenced by name from a repository exist in Artifactory.
+//
+// Only the attributes that changed are verified. Artifactory either rejects an unknown name with an opaque
+// 400 or silently ignores it, so this is done before the repository is sent, and the error names
+// every missing object.
+//
+// This is not done in CustomizeDiff: an object created in the same run (e.g. `artifactory_keypair`) has its
+// name known at plan time, but doesn't exist in Artifactory until it is applied.
+func VerifyConfigurationReferences(d *schema.ResourceData, m interface{}) error {
+	lookup := &referenceLookup{m: m}
+	var missing []string
+
+	for _, reference := range configurationReferences {
+		if _, ok := d.GetOk(reference.attribute); !ok || !d.HasChange(reference.attribute) {
+			continue
+		}
+
+		var names []string
+		switch v := d.Get(reference.attribute).(type) {
+		case string:
+			names = []string{v}
+		case *schema.Set:
+			names = util.CastToStringArr(v.List())
+		case []interface{}:
+			names = util.CastToStringArr(v)
+		}
+
+		for _, name := range names {
+			if name == "" {
+				continue
+			}
+
+			exists, err := reference.exists(name, lookup)
+			if err != nil {
+				return fmt.Errorf("failed to verify %s '%s' referenced by '%s': %s", reference.objectType, name, reference.attribute, err)
+			}
+			if !exists {
+				missing = append(missing, fmt.Sprintf("%s '%s' referenced by '%s' does not exist", reference.objectType, name, reference.attribute))
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("invalid repository configuration: %s", strings.Join(missing, "; "))
+	}
+
+	return nil
+}
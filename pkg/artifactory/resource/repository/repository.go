@@ -133,6 +133,11 @@ func MkRepoCreate(unpack unpacker.UnpackFunc, read schema.ReadContextFunc) schem
 		if err != nil {
 			return diag.FromErr(err)
 		}
+
+		if err := VerifyConfigurationReferences(d, m); err != nil {
+			return diag.FromErr(err)
+		}
+
 		// repo must be a pointer
 		_, err = m.(util.ProvderMetadata).Client.R().
 			AddRetryCondition(client.RetryOnMergeError).
@@ -179,6 +184,10 @@ func MkRepoUpdate(unpack unpacker.UnpackFunc, read schema.ReadContextFunc) schem
 			return diag.FromErr(err)
 		}
 
+		if err := VerifyConfigurationReferences(d, m); err != nil {
+			return diag.FromErr(err)
+		}
+
 		_, err = m.(util.ProvderMetadata).Client.R().
 			AddRetryCondition(client.RetryOnMergeError).
 			SetBody(repo).
@@ -408,3 +417,13 @@ func GetDefaultRepoLayoutRef(repositoryType string, packageType string) func() (
 		return "", fmt.Errorf("default repo layout not found for repository type %v & package type %v", repositoryType, packageType)
 	}
 }
+
+// IsDefaultRepoLayoutRef return true if the layout name is one of the built-in layouts used as package type defaults
+func IsDefaultRepoLayoutRef(name string) bool {
+	for _, supportedRepoClasses := range defaultRepoLayoutMap {
+		if supportedRepoClasses.RepoLayoutRef == name {
+			return true
+		}
+	}
+	return false
+}
@@ -255,3 +255,56 @@ func TestAccRepository_invalid_project_environments_after_7_53_1(t *testing.T) {
 		},
 	})
 }
+
+func TestAccRepository_missing_configuration_references(t *testing.T) {
+	_, fqrn, name := test.MkNames("generic-local", "artifactory_local_generic_repository")
+
+	localRepository := util.ExecuteTemplate("TestAccLocalGenericRepository", `
+		resource "artifactory_local_generic_repository" "{{ .name }}" {
+		  key             = "{{ .name }}"
+		  repo_layout_ref = "{{ .name }}-layout"
+		  property_sets   = ["artifactory", "{{ .name }}-property-set"]
+		}
+	`, map[string]interface{}{
+		"name": name,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.VerifyDeleted(fqrn, acctest.CheckRepo),
+		Steps: []resource.TestStep{
+			{
+				Config:      localRepository,
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`repository layout '%s-layout' referenced by 'repo_layout_ref' does not exist; property set '%s-property-set' referenced by 'property_sets' does not exist`, name, name)),
+			},
+		},
+	})
+}
+
+func TestAccRepository_missing_remote_configuration_references(t *testing.T) {
+	_, fqrn, name := test.MkNames("generic-remote", "artifactory_remote_generic_repository")
+
+	remoteRepository := util.ExecuteTemplate("TestAccRemoteGenericRepository", `
+		resource "artifactory_remote_generic_repository" "{{ .name }}" {
+		  key                    = "{{ .name }}"
+		  url                    = "http://tempurl.org/"
+		  proxy                  = "{{ .name }}-proxy"
+		  client_tls_certificate = "{{ .name }}-certificate"
+		}
+	`, map[string]interface{}{
+		"name": name,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.VerifyDeleted(fqrn, acctest.CheckRepo),
+		Steps: []resource.TestStep{
+			{
+				Config:      remoteRepository,
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`proxy '%s-proxy' referenced by 'proxy' does not exist; certificate '%s-certificate' referenced by 'client_tls_certificate' does not exist`, name, name)),
+			},
+		},
+	})
+}
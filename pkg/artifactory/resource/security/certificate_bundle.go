@@ -0,0 +1,260 @@
+package security
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+
+	// golang.org/x/crypto/pkcs12 is frozen, but it only decodes here, and it supports the PBE-SHA1-3DES and RC2
+	// encryptions of the PKCS#12 bundles exported by most tools. Bundles encrypted with PBES2, the default of OpenSSL
+	// 3, are rejected with an explicit error.
+	"golang.org/x/crypto/pkcs12"
+)
+
+// CertificateBundle is the leaf certificate, followed by its chain, and the optional private key of a PEM bundle
+type CertificateBundle struct {
+	Certificates []*x509.Certificate
+	PrivateKey   crypto.PrivateKey
+}
+
+func (b CertificateBundle) Leaf() *x509.Certificate {
+	return b.Certificates[0]
+}
+
+type publicKey interface {
+	Equal(crypto.PublicKey) bool
+}
+
+type privateKey interface {
+	Public() crypto.PublicKey
+}
+
+// the PKCS#12 decoder emits PKCS #1 and EC keys in "PRIVATE KEY" blocks, so all the encodings are tried for each block
+func parsePrivateKey(der []byte) (crypto.PrivateKey, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("failed to parse private key")
+}
+
+func keyMatchesCertificate(key crypto.PrivateKey, cert *x509.Certificate) bool {
+	private, ok := key.(privateKey)
+	if !ok {
+		return false
+	}
+	public, ok := private.Public().(publicKey)
+	return ok && public.Equal(cert.PublicKey)
+}
+
+// parseCertificateBlocks parses the certificates and the private key of the PEM blocks. Unless strict, a private key
+// which can't be parsed is ignored.
+func parseCertificateBlocks(blocks []*pem.Block, strict bool) (*CertificateBundle, error) {
+	bundle := CertificateBundle{}
+	for _, block := range blocks {
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			bundle.Certificates = append(bundle.Certificates, cert)
+		case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+			if bundle.PrivateKey != nil {
+				return nil, fmt.Errorf("more than one private key in PEM data")
+			}
+			key, err := parsePrivateKey(block.Bytes)
+			if err != nil {
+				if !strict {
+					continue
+				}
+				return nil, err
+			}
+			bundle.PrivateKey = key
+		}
+	}
+
+	if len(bundle.Certificates) == 0 {
+		return nil, fmt.Errorf("no certificate in PEM data")
+	}
+
+	return &bundle, nil
+}
+
+// orderCertificates puts the leaf certificate first, followed by its issuers, as the certificates of a bundle are not
+// always in order. The leaf is the certificate of the private key, or else the first certificate which issued none of
+// the others. The certificates outside the chain of the leaf are dropped.
+func (b *CertificateBundle) orderCertificates() error {
+	var leaf *x509.Certificate
+	if b.PrivateKey != nil {
+		for _, cert := range b.Certificates {
+			if keyMatchesCertificate(b.PrivateKey, cert) {
+				leaf = cert
+				break
+			}
+		}
+		if leaf == nil {
+			return fmt.Errorf("private key doesn't match any certificate")
+		}
+	} else {
+		leaf = findLeaf(b.Certificates)
+	}
+
+	b.Certificates = orderChain(leaf, b.Certificates)
+	return nil
+}
+
+// findLeaf returns the first certificate which isn't the issuer of another certificate
+func findLeaf(certs []*x509.Certificate) *x509.Certificate {
+	for _, cert := range certs {
+		issuer := false
+		for _, other := range certs {
+			if other != cert && bytes.Equal(other.RawIssuer, cert.RawSubject) {
+				issuer = true
+				break
+			}
+		}
+		if !issuer {
+			return cert
+		}
+	}
+	return certs[0]
+}
+
+// verify checks that each certificate of the chain is signed by the next one
+func (b CertificateBundle) verify() error {
+	for i := 0; i < len(b.Certificates)-1; i++ {
+		if err := b.Certificates[i].CheckSignatureFrom(b.Certificates[i+1]); err != nil {
+			return fmt.Errorf("certificate %s is not signed by the next certificate in the chain, %s: %s",
+				b.Certificates[i].Subject, b.Certificates[i+1].Subject, err)
+		}
+	}
+
+	return nil
+}
+
+func decodePEMBlocks(pemData string) []*pem.Block {
+	var blocks []*pem.Block
+	block, rest := pem.Decode([]byte(pemData))
+	for block != nil {
+		blocks = append(blocks, block)
+		block, rest = pem.Decode(rest)
+	}
+	return blocks
+}
+
+// ParseCertificateBundle parses and verifies the certificate, chain and private key of a PEM bundle. The
+// certificates can be in any order, and the certificates which aren't part of the chain of the leaf are ignored.
+func ParseCertificateBundle(pemData string) (*CertificateBundle, error) {
+	bundle, err := parseCertificateBlocks(decodePEMBlocks(pemData), true)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bundle.orderCertificates(); err != nil {
+		return nil, err
+	}
+	if err := bundle.verify(); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// ReadCertificateBundle parses the certificates of a PEM bundle without verifying the chain and the private key. The
+// leaf is the certificate of the private key when it matches one, or else the first certificate which issued none of
+// the others.
+func ReadCertificateBundle(pemData string) (*CertificateBundle, error) {
+	bundle, err := parseCertificateBlocks(decodePEMBlocks(pemData), false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bundle.orderCertificates(); err != nil {
+		bundle.Certificates = orderChain(findLeaf(bundle.Certificates), bundle.Certificates)
+	}
+
+	return bundle, nil
+}
+
+// PKCS12ToPEM converts a base64 encoded PKCS#12 bundle to the PEM bundle expected by Artifactory: the private key,
+// the leaf certificate and its chain, in order
+func PKCS12ToPEM(pkcs12Content, password string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(pkcs12Content)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode PKCS#12 content: %s", err)
+	}
+
+	blocks, err := pkcs12.ToPEM(data, password)
+	if err != nil {
+		if _, unsupported := err.(pkcs12.NotImplementedError); unsupported {
+			return "", fmt.Errorf("failed to decode PKCS#12 content: %s. Bundles encrypted with PBES2, the default of "+
+				"OpenSSL 3, aren't supported: export the bundle with the legacy algorithms, e.g. with the -legacy option of openssl pkcs12", err)
+		}
+		return "", fmt.Errorf("failed to decode PKCS#12 content: %s", err)
+	}
+
+	bundle, err := parseCertificateBlocks(blocks, true)
+	if err != nil {
+		return "", err
+	}
+	if bundle.PrivateKey == nil {
+		return "", fmt.Errorf("no private key in PKCS#12 content")
+	}
+
+	if err := bundle.orderCertificates(); err != nil {
+		return "", err
+	}
+	if err := bundle.verify(); err != nil {
+		return "", err
+	}
+
+	key, err := x509.MarshalPKCS8PrivateKey(bundle.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	if err := pem.Encode(&out, &pem.Block{Type: "PRIVATE KEY", Bytes: key}); err != nil {
+		return "", err
+	}
+	for _, cert := range bundle.Certificates {
+		if err := pem.Encode(&out, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return "", err
+		}
+	}
+
+	return out.String(), nil
+}
+
+// orderChain returns the leaf followed by its issuers, the certificates outside the chain are dropped
+func orderChain(leaf *x509.Certificate, certs []*x509.Certificate) []*x509.Certificate {
+	chain := []*x509.Certificate{leaf}
+	used := map[*x509.Certificate]bool{leaf: true}
+
+	for current := leaf; !bytes.Equal(current.RawIssuer, current.RawSubject); {
+		var issuer *x509.Certificate
+		for _, cert := range certs {
+			if !used[cert] && bytes.Equal(cert.RawSubject, current.RawIssuer) {
+				issuer = cert
+				break
+			}
+		}
+		if issuer == nil {
+			break
+		}
+		chain = append(chain, issuer)
+		used[issuer] = true
+		current = issuer
+	}
+
+	return chain
+}
@@ -0,0 +1,60 @@
+package security
+
+import (
+	"strconv"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const (
+	AccessGroupsEndpoint = "access/api/v2/groups"
+	// GroupsListPageSize is the number of groups requested per page of the Access groups API
+	GroupsListPageSize = 100
+)
+
+type groupSummary struct {
+	Name string `json:"group_name"`
+}
+
+type groupList struct {
+	Groups []groupSummary `json:"groups"`
+	Cursor string         `json:"cursor"`
+}
+
+// ListGroupNames returns the names of all the groups, following the cursor of the Access groups API page after page
+func ListGroupNames(m interface{}) ([]string, error) {
+	var names []string
+	cursor := ""
+	for {
+		page := groupList{}
+		req := m.(util.ProvderMetadata).Client.R().
+			SetQueryParam("limit", strconv.Itoa(GroupsListPageSize)).
+			SetResult(&page)
+		if cursor != "" {
+			req.SetQueryParam("cursor", cursor)
+		}
+		if _, err := req.Get(AccessGroupsEndpoint); err != nil {
+			return nil, err
+		}
+
+		for _, group := range page.Groups {
+			names = append(names, group.Name)
+		}
+		// the last page has no cursor, a cursor which doesn't move would loop forever
+		if page.Cursor == "" || page.Cursor == cursor || len(page.Groups) == 0 {
+			return names, nil
+		}
+		cursor = page.Cursor
+	}
+}
+
+// GroupFilter selects groups on their details. The zero value matches all the groups.
+type GroupFilter struct {
+	Realm      string
+	ExternalId string
+}
+
+func (f GroupFilter) Matches(group Group) bool {
+	return (f.Realm == "" || f.Realm == group.Realm) &&
+		(f.ExternalId == "" || f.ExternalId == group.ExternalId)
+}
@@ -0,0 +1,147 @@
+package security
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+type KeyPairGenerationOptions struct {
+	PairType   string
+	KeySize    int
+	Name       string
+	Email      string
+	ExpiresIn  int
+	Passphrase string
+}
+
+type GeneratedKeyPair struct {
+	PrivateKey  string
+	PublicKey   string
+	Fingerprint string
+}
+
+// GenerateKeyPair creates the key material of an RSA key pair, in PEM format, or of a GPG key pair, armored.
+// The identity, expiry and passphrase only apply to GPG key pairs.
+func GenerateKeyPair(options KeyPairGenerationOptions) (*GeneratedKeyPair, error) {
+	switch options.PairType {
+	case "RSA":
+		return generateRSAKeyPair(options)
+	case "GPG":
+		return generateGPGKeyPair(options)
+	default:
+		return nil, fmt.Errorf("unsupported key pair type %s", options.PairType)
+	}
+}
+
+// KeyPairFingerprint returns the SHA-256 fingerprint of the DER encoded RSA public key, or the fingerprint of the
+// primary GPG public key, in upper case hexadecimal
+func KeyPairFingerprint(publicKey string) (string, error) {
+	publicKey = strings.ReplaceAll(publicKey, "\t", "")
+
+	if strings.Contains(publicKey, "BEGIN PGP PUBLIC KEY BLOCK") {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+		if err != nil {
+			return "", err
+		}
+		if len(entities) == 0 {
+			return "", fmt.Errorf("no GPG public key found")
+		}
+		return strings.ToUpper(hex.EncodeToString(entities[0].PrimaryKey.Fingerprint)), nil
+	}
+
+	pubPem, _ := pem.Decode([]byte(publicKey))
+	if pubPem == nil {
+		return "", fmt.Errorf("rsa public key not in pem format")
+	}
+	fingerprint := sha256.Sum256(pubPem.Bytes)
+	return strings.ToUpper(hex.EncodeToString(fingerprint[:])), nil
+}
+
+func generateRSAKeyPair(options KeyPairGenerationOptions) (*GeneratedKeyPair, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, options.KeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}))
+	fingerprint, err := KeyPairFingerprint(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GeneratedKeyPair{
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})),
+		PublicKey:   publicKey,
+		Fingerprint: fingerprint,
+	}, nil
+}
+
+func generateGPGKeyPair(options KeyPairGenerationOptions) (*GeneratedKeyPair, error) {
+	config := &packet.Config{
+		RSABits:         options.KeySize,
+		KeyLifetimeSecs: uint32(options.ExpiresIn),
+	}
+
+	entity, err := openpgp.NewEntity(options.Name, "", options.Email, config)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := bytes.Buffer{}
+	publicKeyWriter, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.Serialize(publicKeyWriter); err != nil {
+		return nil, err
+	}
+	if err := publicKeyWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	// the identities and subkeys are already signed by NewEntity, so the keys are encrypted before the serialization
+	if options.Passphrase != "" {
+		if err := entity.PrivateKey.Encrypt([]byte(options.Passphrase)); err != nil {
+			return nil, err
+		}
+		for _, subkey := range entity.Subkeys {
+			if err := subkey.PrivateKey.Encrypt([]byte(options.Passphrase)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	privateKey := bytes.Buffer{}
+	privateKeyWriter, err := armor.Encode(&privateKey, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.SerializePrivateWithoutSigning(privateKeyWriter, config); err != nil {
+		return nil, err
+	}
+	if err := privateKeyWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return &GeneratedKeyPair{
+		PrivateKey:  privateKey.String() + "\n",
+		PublicKey:   publicKey.String() + "\n",
+		Fingerprint: strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint)),
+	}, nil
+}
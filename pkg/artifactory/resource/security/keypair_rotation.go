@@ -0,0 +1,129 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/repository"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const RepositoriesListEndpoint = "artifactory/api/repositories"
+
+// only the Alpine, Debian and RPM repositories sign their metadata with key pairs
+var keyPairPackageTypes = map[string]bool{
+	"alpine": true,
+	"debian": true,
+	"rpm":    true,
+}
+
+type repositorySummary struct {
+	Key         string `json:"key"`
+	PackageType string `json:"packageType"`
+}
+
+type repositoryKeyPairRefs struct {
+	Key                 string `json:"key,omitempty"`
+	PrimaryKeyPairRef   string `json:"primaryKeyPairRef"`
+	SecondaryKeyPairRef string `json:"secondaryKeyPairRef"`
+}
+
+func getKeyPairRepositories(m interface{}) ([]repositoryKeyPairRefs, error) {
+	var summaries []repositorySummary
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetResult(&summaries).
+		Get(RepositoriesListEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var repositories []repositoryKeyPairRefs
+	for _, summary := range summaries {
+		if !keyPairPackageTypes[strings.ToLower(summary.PackageType)] {
+			continue
+		}
+
+		refs := repositoryKeyPairRefs{}
+		_, err := m.(util.ProvderMetadata).Client.R().
+			SetPathParam("key", summary.Key).
+			SetResult(&refs).
+			Get(repository.RepositoriesEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		refs.Key = summary.Key
+		repositories = append(repositories, refs)
+	}
+
+	return repositories, nil
+}
+
+func updateRepositoryKeyPairRefs(refs repositoryKeyPairRefs, m interface{}) error {
+	_, err := m.(util.ProvderMetadata).Client.R().
+		AddRetryCondition(client.RetryOnMergeError).
+		SetPathParam("key", refs.Key).
+		SetBody(repositoryKeyPairRefs{
+			PrimaryKeyPairRef:   refs.PrimaryKeyPairRef,
+			SecondaryKeyPairRef: refs.SecondaryKeyPairRef,
+		}).
+		Post(repository.RepositoriesEndpoint)
+	return err
+}
+
+// RelinkKeyPairRepositories makes the new key pair the primary key pair of the repositories signed with the old one,
+// and the old key pair their secondary key pair, so clients can still verify the metadata signed before the rotation.
+// It returns the keys of the updated repositories.
+func RelinkKeyPairRepositories(ctx context.Context, oldPairName, newPairName string, m interface{}) ([]string, error) {
+	repositories, err := getKeyPairRepositories(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the repositories using key pair %s: %s", oldPairName, err)
+	}
+
+	var relinked []string
+	for _, refs := range repositories {
+		if refs.PrimaryKeyPairRef != oldPairName {
+			continue
+		}
+
+		refs.PrimaryKeyPairRef = newPairName
+		refs.SecondaryKeyPairRef = oldPairName
+		if err := updateRepositoryKeyPairRefs(refs, m); err != nil {
+			return relinked, fmt.Errorf("failed to link repository %s to key pair %s: %s", refs.Key, newPairName, err)
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("repository %s relinked from key pair %s to %s", refs.Key, oldPairName, newPairName))
+		relinked = append(relinked, refs.Key)
+	}
+
+	return relinked, nil
+}
+
+// UnlinkKeyPairRepositories removes the key pair from the secondary key pair of the repositories, before it is deleted.
+// It returns the keys of the updated repositories.
+func UnlinkKeyPairRepositories(ctx context.Context, pairName string, m interface{}) ([]string, error) {
+	repositories, err := getKeyPairRepositories(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the repositories using key pair %s: %s", pairName, err)
+	}
+
+	var unlinked []string
+	for _, refs := range repositories {
+		if refs.SecondaryKeyPairRef != pairName {
+			continue
+		}
+
+		refs.SecondaryKeyPairRef = ""
+		if err := updateRepositoryKeyPairRefs(refs, m); err != nil {
+			return unlinked, fmt.Errorf("failed to unlink repository %s from key pair %s: %s", refs.Key, pairName, err)
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("repository %s unlinked from key pair %s", refs.Key, pairName))
+		unlinked = append(unlinked, refs.Key)
+	}
+
+	return unlinked, nil
+}
@@ -0,0 +1,179 @@
+package security_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/security"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// repositoriesStandIn answers the repositories endpoints the way Artifactory does, for the repositories and key pair
+// references in refs. The updates of the key pair references are applied to refs.
+func repositoriesStandIn(t *testing.T, packageTypes map[string]string, refs map[string]map[string]string) util.ProvderMetadata {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/"+security.RepositoriesListEndpoint {
+			var repositories []map[string]string
+			for key, packageType := range packageTypes {
+				repositories = append(repositories, map[string]string{"key": key, "type": "LOCAL", "packageType": packageType})
+			}
+			_ = json.NewEncoder(w).Encode(repositories)
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/"+security.RepositoriesListEndpoint+"/")
+		if _, ok := packageTypes[key]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			if strings.ToLower(packageTypes[key]) == "generic" {
+				t.Errorf("unexpected lookup of the %s repository", key)
+			}
+			_ = json.NewEncoder(w).Encode(refs[key])
+		case http.MethodPost:
+			update := map[string]string{}
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Fatal(err)
+			}
+			refs[key] = update
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return util.ProvderMetadata{Client: restyClient}
+}
+
+func TestRelinkKeyPairRepositories(t *testing.T) {
+	refs := map[string]map[string]string{
+		"debian-local": {"primaryKeyPairRef": "signing", "secondaryKeyPairRef": ""},
+		"rpm-local":    {"primaryKeyPairRef": "signing", "secondaryKeyPairRef": "signing-old"},
+		"alpine-local": {"primaryKeyPairRef": "other", "secondaryKeyPairRef": "signing"},
+	}
+	m := repositoriesStandIn(t, map[string]string{
+		"debian-local":  "Debian",
+		"rpm-local":     "RPM",
+		"alpine-local":  "Alpine",
+		"generic-local": "Generic",
+	}, refs)
+
+	relinked, err := security.RelinkKeyPairRepositories(context.Background(), "signing", "signing-1666051200", m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(relinked) != 2 {
+		t.Errorf("expected 2 relinked repositories, got %v", relinked)
+	}
+	expected := map[string]map[string]string{
+		"debian-local": {"primaryKeyPairRef": "signing-1666051200", "secondaryKeyPairRef": "signing"},
+		"rpm-local":    {"primaryKeyPairRef": "signing-1666051200", "secondaryKeyPairRef": "signing"},
+		"alpine-local": {"primaryKeyPairRef": "other", "secondaryKeyPairRef": "signing"},
+	}
+	if !reflect.DeepEqual(expected, refs) {
+		t.Fatalf("expected: %v\n\ngot: %v", expected, refs)
+	}
+}
+
+func TestUnlinkKeyPairRepositories(t *testing.T) {
+	refs := map[string]map[string]string{
+		"debian-local": {"primaryKeyPairRef": "signing-1666051200", "secondaryKeyPairRef": "signing"},
+		"rpm-local":    {"primaryKeyPairRef": "signing", "secondaryKeyPairRef": "other"},
+	}
+	m := repositoriesStandIn(t, map[string]string{
+		"debian-local": "Debian",
+		"rpm-local":    "RPM",
+	}, refs)
+
+	unlinked, err := security.UnlinkKeyPairRepositories(context.Background(), "signing", m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual([]string{"debian-local"}, unlinked) {
+		t.Errorf("expected debian-local to be unlinked, got %v", unlinked)
+	}
+	expected := map[string]map[string]string{
+		"debian-local": {"primaryKeyPairRef": "signing-1666051200", "secondaryKeyPairRef": ""},
+		"rpm-local":    {"primaryKeyPairRef": "signing", "secondaryKeyPairRef": "other"},
+	}
+	if !reflect.DeepEqual(expected, refs) {
+		t.Fatalf("expected: %v\n\ngot: %v", expected, refs)
+	}
+}
+
+func TestKeyPairRotationWithFailedRelinking(t *testing.T) {
+	var postedPairName string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/"+security.KeypairEndPoint:
+			keyPair := security.KeyPairPayLoad{}
+			if err := json.NewDecoder(r.Body).Decode(&keyPair); err != nil {
+				t.Fatal(err)
+			}
+			postedPairName = keyPair.PairName
+		case r.Method == http.MethodGet && r.URL.Path == "/"+security.RepositoriesListEndpoint:
+			_, _ = w.Write([]byte(`[{"key":"rpm-local","type":"LOCAL","packageType":"RPM"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/"+security.RepositoriesListEndpoint+"/rpm-local":
+			_, _ = w.Write([]byte(`{"primaryKeyPairRef":"signing","secondaryKeyPairRef":""}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/"+security.RepositoriesListEndpoint+"/rpm-local":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyPairResource := security.ResourceArtifactoryKeyPair()
+	d := schema.TestResourceDataRaw(t, keyPairResource.Schema, map[string]interface{}{
+		"pair_name":   "signing",
+		"pair_type":   "RSA",
+		"alias":       "signing",
+		"private_key": "fake-private-key",
+		"public_key":  "fake-public-key",
+		"rotation":    []interface{}{map[string]interface{}{"grace_period": 3600, "relink_repositories": true}},
+	})
+	d.SetId("signing")
+
+	diags := keyPairResource.UpdateContext(context.Background(), d, util.ProvderMetadata{Client: restyClient})
+	if !diags.HasError() {
+		t.Fatal("expected the relinking to fail")
+	}
+
+	if postedPairName == "" || d.Id() != postedPairName {
+		t.Errorf("expected the new key pair %q to be saved, got id %q", postedPairName, d.Id())
+	}
+	if previousPairName := d.Get("previous_pair_name").(string); previousPairName != "signing" {
+		t.Errorf("expected previous_pair_name to be signing, got %q", previousPairName)
+	}
+	if !d.Get("relink_pending").(bool) {
+		t.Error("expected relink_pending to be set for the next apply")
+	}
+	if relinked := d.Get("relinked_repositories").([]interface{}); len(relinked) != 0 {
+		t.Errorf("expected no relinked repositories, got %v", relinked)
+	}
+}
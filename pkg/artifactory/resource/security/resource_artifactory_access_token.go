@@ -2,17 +2,16 @@ package security
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
-	"github.com/google/go-querystring/query"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -20,132 +19,136 @@ import (
 	"github.com/jfrog/terraform-provider-shared/util"
 )
 
-// AccessTokenRevokeOptions jfrog client go has no v1 code and moving to v2 would be a lot of work.
-// To remove the dependency, we copy and past it here
-type AccessTokenRevokeOptions struct {
-	Token string `url:"token,omitempty"`
-}
+const ServiceIdEndpoint = "artifactory/api/system/service_id"
+
+var accessTokenAudienceRegex = regexp.MustCompile(`^(jfrt|jfxr|jfpip|jfds|jfmc|jfac|jfevt|jfmd|jfcon|\*)@.+$`)
+
+var accessTokenSchema = map[string]*schema.Schema{
+	"username": {
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	},
+	"audience": {
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+		ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+			v, ok := i.(string)
+			if !ok {
+				return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+			}
+
+			for _, audience := range strings.Fields(v) {
+				if !accessTokenAudienceRegex.MatchString(audience) {
+					return nil, []error{fmt.Errorf("audience can contain only service IDs of Artifactory servers, e.g. `jfrt@*`, got %q", audience)}
+				}
+			}
 
-type AccessTokenOptions struct {
-	// The grant type used to authenticate the request. In this case, the only value supported is "client_credentials" which is also the default value if this parameter is not specified.
-	GrantType string `url:"grant_type,omitempty"` // [Optional, default: "client_credentials"]
-	// The user name for which this token is created. If the user does not exist, a transient user is created. Non-admin users can only create tokens for themselves so they must specify their own username.
-	// If the user does not exist, the member-of-groups scope token must be provided (e.g. member-of-groups: g1, g2, g3...)
-	Username string `url:"username,omitempty"`
-	// The scope to assign to the token provided as a space-separated list of scope tokens. Currently there are three possible scope tokens:
-	//     - "api:*" - indicates that the token grants access to REST API calls. This is always granted by default whether specified in the call or not.
-	//     - member-of-groups:[<group-name>] - indicates the groups that the token is associated with (e.g. member-of-groups: g1, g2, g3...). The token grants access according to the permission targets specified for the groups listed.
-	//       Specify "*" for group-name to indicate that the token should provide the same access privileges that are given to the group of which the logged in user is a member.
-	//       A non-admin user can only provide a scope that is a subset of the groups to which he belongs
-	//     - "jfrt@<instance-id>:admin" - provides admin privileges on the specified Artifactory instance. This is only available for administrators.
-	// If omitted and the username specified exists, the token is granted the scope of that user.
-	Scope string `url:"scope,omitempty"` // [Optional if the user specified in username exists]
-	// The time in seconds for which the token will be valid. To specify a token that never expires, set to zero. Non-admin can only set a value that is equal to or less than the default 3600.
-	ExpiresIn int `url:"expires_in"` // [Optional, default: 3600]
-	// If true, this token is refreshable and the refresh token can be used to replace it with a new token once it expires.
-	Refreshable string `url:"refreshable,omitempty"` // [Optional, default: false]
-	// A space-separate list of the other Artifactory instances or services that should accept this token identified by their Artifactory Service IDs as obtained from the Get Service ID endpoint.
-	// In case you want the token to be accepted by all Artifactory instances you may use the following audience parameter "audience=jfrt@*".
-	Audience string `url:"audience,omitempty"` // [Optional, default: Only the Service ID of the Artifactory instance that created the token]
+			return nil, nil
+		},
+	},
+	"groups": {
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
+	"end_date_relative": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"end_date"},
+		ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+			v, ok := i.(string)
+			if !ok {
+				return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+			}
+
+			if strings.TrimSpace(v) == "" {
+				return nil, []error{fmt.Errorf("%q must not be empty", k)}
+			}
+
+			return nil, nil
+		},
+		AtLeastOneOf: []string{"end_date", "end_date_relative"},
+	},
+	"end_date": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		Computed:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"end_date_relative"},
+		ValidateFunc: func(i interface{}, k string) (warnings []string, errors []error) {
+			v, ok := i.(string)
+			if !ok {
+				errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+				return warnings, errors
+			}
+
+			if _, err := time.Parse(time.RFC3339, v); err != nil {
+				errors = append(errors, fmt.Errorf("expected %q to be a valid RFC3339 date, got %q: %+v", k, i, err))
+			}
+
+			return warnings, errors
+		},
+		AtLeastOneOf: []string{"end_date", "end_date_relative"},
+	},
+	"refreshable": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		ForceNew: true,
+	},
+	"admin_token": {
+		Type:          schema.TypeSet,
+		ConflictsWith: []string{"groups"},
+		Optional:      true,
+		MaxItems:      1,
+		MinItems:      1,
+		ForceNew:      true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"instance_id": {
+					Type:     schema.TypeString,
+					Required: true,
+					ForceNew: true,
+				},
+			},
+		},
+	},
+	"access_token": {
+		Type:      schema.TypeString,
+		Computed:  true,
+		Sensitive: true,
+	},
+	"refresh_token": {
+		Type:      schema.TypeString,
+		Computed:  true,
+		Sensitive: true,
+	},
 }
 
 func ResourceArtifactoryAccessToken() *schema.Resource {
+	var resourceAccessTokenV0 = &schema.Resource{
+		Schema: accessTokenSchema,
+	}
+
 	return &schema.Resource{
 		CreateContext: resourceAccessTokenCreate,
 		ReadContext:   resourceAccessTokenRead,
 		DeleteContext: resourceAccessTokenDelete,
 
-		Schema: map[string]*schema.Schema{
-			"username": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-			"audience": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-			},
-			"groups": {
-				Type:     schema.TypeList,
-				Optional: true,
-				ForceNew: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
-			"end_date_relative": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ForceNew:      true,
-				ConflictsWith: []string{"end_date"},
-				ValidateFunc: func(i interface{}, k string) ([]string, []error) {
-					v, ok := i.(string)
-					if !ok {
-						return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
-					}
-
-					if strings.TrimSpace(v) == "" {
-						return nil, []error{fmt.Errorf("%q must not be empty", k)}
-					}
-
-					return nil, nil
-				},
-				AtLeastOneOf: []string{"end_date", "end_date_relative"},
-			},
-			"end_date": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Computed:      true,
-				ForceNew:      true,
-				ConflictsWith: []string{"end_date_relative"},
-				ValidateFunc: func(i interface{}, k string) (warnings []string, errors []error) {
-					v, ok := i.(string)
-					if !ok {
-						errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
-						return warnings, errors
-					}
-
-					if _, err := time.Parse(time.RFC3339, v); err != nil {
-						errors = append(errors, fmt.Errorf("expected %q to be a valid RFC3339 date, got %q: %+v", k, i, err))
-					}
-
-					return warnings, errors
-				},
-				AtLeastOneOf: []string{"end_date", "end_date_relative"},
-			},
-			"refreshable": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
-				ForceNew: true,
-			},
-			"admin_token": {
-				Type:          schema.TypeSet,
-				ConflictsWith: []string{"groups"},
-				Optional:      true,
-				MaxItems:      1,
-				MinItems:      1,
-				ForceNew:      true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"instance_id": {
-							Type:     schema.TypeString,
-							Required: true,
-							ForceNew: true,
-						},
-					},
-				},
-			},
-			"access_token": {
-				Type:      schema.TypeString,
-				Computed:  true,
-				Sensitive: true,
-			},
-			"refresh_token": {
-				Type:      schema.TypeString,
-				Computed:  true,
-				Sensitive: true,
+		Schema: accessTokenSchema,
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceAccessTokenV0.CoreConfigSchema().ImpliedType(),
+				Upgrade: ResourceAccessTokenStateUpgradeV0,
+				Version: 0,
 			},
 		},
 
@@ -153,20 +156,114 @@ func ResourceArtifactoryAccessToken() *schema.Resource {
 	}
 }
 
-func resourceAccessTokenCreate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+// AccessTokenScope converts the groups and admin token of the access token into the scope of the Access API.
+// The `*` group, all the groups of the user, is the user scope.
+func AccessTokenScope(groups []string, admin bool) string {
+	if admin {
+		return "applied-permissions/admin"
+	}
+
+	if len(groups) == 0 {
+		return ""
+	}
+
+	quotedGroups := make([]string, len(groups))
+	for i, group := range groups {
+		if group == "*" {
+			return "applied-permissions/user"
+		}
 
-	type AccessToken struct {
-		AccessToken  string `json:"access_token,omitempty"`
-		ExpiresIn    int    `json:"expires_in,omitempty"`
-		Scope        string `json:"scope,omitempty"`
-		TokenType    string `json:"token_type,omitempty"`
-		RefreshToken string `json:"refresh_token,omitempty"`
+		quotedGroups[i] = group
+		if strings.ContainsAny(group, " ,") {
+			quotedGroups[i] = fmt.Sprintf("%q", group)
+		}
 	}
 
-	client := m.(util.ProvderMetadata).Client
-	grantType := "client_credentials" // client_credentials is the only supported type
+	return "applied-permissions/groups:" + strings.Join(quotedGroups, ",")
+}
 
-	tokenOptions := AccessTokenOptions{}
+// AccessTokenPersistencyThreshold is the default persistency threshold of Access: the tokens which expire sooner
+// are not saved by Access, so they can't be read back
+const AccessTokenPersistencyThreshold = 10800 * time.Second
+
+type accessTokenClaims struct {
+	Jti string `json:"jti"`
+	Iat int64  `json:"iat"`
+	Exp int64  `json:"exp"`
+}
+
+func parseAccessTokenClaims(accessToken string) (*accessTokenClaims, error) {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("access token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the access token payload: %s", err)
+	}
+
+	claims := accessTokenClaims{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to parse the access token payload: %s", err)
+	}
+
+	return &claims, nil
+}
+
+// AccessTokenId returns the ID of a JWT access token, from its `jti` claim
+func AccessTokenId(accessToken string) (string, error) {
+	claims, err := parseAccessTokenClaims(accessToken)
+	if err != nil {
+		return "", err
+	}
+	if claims.Jti == "" {
+		return "", fmt.Errorf("access token has no jti claim")
+	}
+
+	return claims.Jti, nil
+}
+
+// IsTransientAccessToken returns true when the access token expires before AccessTokenPersistencyThreshold, from
+// its `iat` and `exp` claims. Non-expiring tokens, and tokens which can't be parsed, are not transient.
+func IsTransientAccessToken(accessToken string) bool {
+	claims, err := parseAccessTokenClaims(accessToken)
+	if err != nil || claims.Exp == 0 {
+		return false
+	}
+
+	return time.Duration(claims.Exp-claims.Iat)*time.Second < AccessTokenPersistencyThreshold
+}
+
+// ResourceAccessTokenStateUpgradeV0 replaces the ID of tokens created with the deprecated
+// `artifactory/api/security/token` API, a hash of the access token, with the ID of the token in Access.
+// See the corresponding unit test TestAccessTokenResourceStateUpgradeV0
+func ResourceAccessTokenStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	accessToken, _ := rawState["access_token"].(string)
+
+	tokenId, err := AccessTokenId(accessToken)
+	if err != nil {
+		// the token is removed from the state by the next read, and created again
+		tflog.Warn(ctx, fmt.Sprintf("Unable to get the ID of access token %s: %s", rawState["id"], err))
+		return rawState, nil
+	}
+
+	rawState["id"] = tokenId
+
+	return rawState, nil
+}
+
+func resourceAccessTokenCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	type AccessTokenPostRequest struct {
+		GrantType   string `json:"grant_type"`
+		Username    string `json:"username"`
+		Scope       string `json:"scope,omitempty"`
+		ExpiresIn   int    `json:"expires_in"`
+		Refreshable bool   `json:"refreshable"`
+		Audience    string `json:"audience,omitempty"`
+	}
+
+	client := m.(util.ProvderMetadata).Client
 	resourceData := &util.ResourceData{ResourceData: d}
 
 	date, expiresIn, err := getDate(d)
@@ -174,159 +271,128 @@ func resourceAccessTokenCreate(_ context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
-	tokenOptions.ExpiresIn = expiresIn
 	err = d.Set("end_date", date.Format(time.RFC3339))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	refreshable := resourceData.Get("refreshable").(bool)
-	audience := d.Get("audience").(string)
-
-	tokenOptions.Audience = audience
-	tokenOptions.GrantType = grantType
-	tokenOptions.Refreshable = strconv.FormatBool(refreshable)
-	tokenOptions.Username = resourceData.GetString("username", false)
-
-	username := resourceData.Get("username").(string)
+	username := resourceData.GetString("username", false)
 	userExists, _ := checkUserExists(client, username)
 
-	if !userExists && len(resourceData.Get("groups").([]interface{})) == 0 {
+	groups := resourceData.GetList("groups")
+	if !userExists && len(groups) == 0 {
 		return diag.Errorf("you must specify at least 1 group when creating a token for a non-existant user - %s, or correct the username", username)
 	}
 
-	err = unpackGroups(d, client, &tokenOptions)
-	if err != nil {
-		return diag.FromErr(err)
+	for _, group := range groups {
+		if group != "*" {
+			if exist, err := checkGroupExists(client, group); !exist {
+				return diag.FromErr(err)
+			}
+		}
 	}
 
-	err = unpackAdminToken(d, &tokenOptions)
-	if err != nil {
-		return diag.FromErr(err)
+	_, isAdmin := d.GetOk("admin_token")
+	if isAdmin {
+		if err := checkAdminTokenInstance(client, d); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	accessToken := AccessToken{}
-	values, err := TokenOptsToValues(tokenOptions)
-	if err != nil {
-		return diag.FromErr(err)
+	refreshable := resourceData.GetBool("refreshable", false)
+	tokenRequest := AccessTokenPostRequest{
+		GrantType:   "client_credentials", // client_credentials is the only supported type
+		Username:    username,
+		Scope:       AccessTokenScope(groups, isAdmin),
+		ExpiresIn:   expiresIn,
+		Refreshable: refreshable,
+		Audience:    resourceData.GetString("audience", false),
 	}
-	_, err = m.(util.ProvderMetadata).Client.R().
-		SetHeader("Content-Type", "application/x-www-form-urlencoded").
-		SetResult(&accessToken).
-		SetFormDataFromValues(values).Post("artifactory/api/security/token")
 
+	result := AccessTokenPostResponse{}
+	_, err = client.R().
+		SetBody(tokenRequest).
+		SetResult(&result).
+		Post(AccessTokensEndpoint)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.Itoa(schema.HashString(accessToken.AccessToken)))
+	d.SetId(result.Id())
 
-	err = d.Set("access_token", accessToken.AccessToken)
+	err = d.Set("access_token", result.AccessToken)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	refreshToken := ""
 	if refreshable {
-		refreshToken = accessToken.RefreshToken
+		refreshToken = result.RefreshToken
 	}
 
 	err = d.Set("refresh_token", refreshToken)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	return nil
-}
 
-func resourceAccessTokenRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
-	// Terraform requires that the read function is always implemented.
-	// However, Artifactory does not have an API to read a token.
-	return nil
+	return resourceAccessTokenRead(ctx, d, m)
 }
 
-func resourceAccessTokenDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	// Artifactory only allows you to revoke a token if the there is no expiry.
-	// Otherwise, Artifactory will ensure the token is revoked at the expiry time.
-	// https://www.jfrog.com/confluence/display/JFROG/Access+Tokens#AccessTokens-ViewingandRevokingTokens
-	// https://www.jfrog.com/jira/browse/RTFACT-15293
-
-	// If relative end date is empty, then a fixed end date was set
-	// Therefore, Artifactory will expire the token automatically
-	endDateRelative := d.Get("end_date_relative").(string)
-	if endDateRelative == "" {
-		tflog.Debug(ctx, "Token is not revoked. It will expire at "+d.Get("end_date").(string))
+func resourceAccessTokenRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// transient tokens are never saved by Access, so they are kept in the state as they were created
+	if IsTransientAccessToken(d.Get("access_token").(string)) {
+		tflog.Debug(ctx, fmt.Sprintf("Access token %s expires before the persistency threshold, it is not read", d.Id()))
 		return nil
 	}
 
-	// Convert end date relative to duration in seconds
-	duration, err := time.ParseDuration(endDateRelative)
-	if err != nil {
-		return diag.Errorf("unable to parse `end_date_relative` (%s) as a duration", endDateRelative)
-	}
-
-	// If the token has no duration, it does not expire.
-	// Therefore revoke the token.
-	if duration.Seconds() == 0 {
-		tflog.Debug(ctx, "Revoking token")
-		revokeOptions := AccessTokenRevokeOptions{}
-		revokeOptions.Token = d.Get("access_token").(string)
-		values, err := query.Values(revokeOptions)
-		resp, err := m.(util.ProvderMetadata).Client.R().
-			SetHeader("Content-Type", "application/x-www-form-urlencoded").
-			SetFormDataFromValues(values).Post("artifactory/api/security/token/revoke")
-		if err != nil {
-			if resp != nil {
-				if resp.StatusCode() == http.StatusNotFound {
-					tflog.Debug(ctx, "Token Revoked")
-					return nil
-				}
-				// the original atlassian code considered any error code fine. However, expiring tokens can't be revoked
-				regex := regexp.MustCompile(`.*Token not revocable.*`)
-				if regex.MatchString(string(resp.Body()[:])) {
-					return nil
-				}
-			}
-			return diag.FromErr(err)
+	// tokens past their end date are expired, unless they never expire
+	if endDate, err := time.Parse(time.RFC3339, d.Get("end_date").(string)); err == nil && endDate.Before(time.Now()) {
+		if duration, err := time.ParseDuration(d.Get("end_date_relative").(string)); err != nil || duration != 0 {
+			id := d.Id()
+			d.SetId("")
+
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Access token %s expired", id),
+				Detail:   "The token is created again by the next apply.",
+			}}
 		}
-		return nil
 	}
 
-	// If the duration is set, Artifactory will automatically revoke the token.
-	tflog.Debug(ctx, "Token is not revoked. It will expire at "+d.Get("end_date").(string))
-
-	return nil
-}
-
-func unpackGroups(d *schema.ResourceData, client *resty.Client, tokenOptions *AccessTokenOptions) error {
-	if srcGroups, ok := d.GetOk("groups"); ok {
-		groups := make([]string, len(srcGroups.([]interface{})))
-		for i, group := range srcGroups.([]interface{}) {
-			groups[i] = group.(string)
-
-			if groups[i] != "*" {
-				if exist, err := checkGroupExists(client, groups[i]); !exist {
-					return err
-				}
-			}
+	resp, err := CheckAccessToken(d.Id(), m.(util.ProvderMetadata).Client.R())
+	if err != nil {
+		if resp != nil && resp.StatusCode() == http.StatusNotFound {
+			id := d.Id()
+			d.SetId("")
+
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Access token %s revoked or not found", id),
+				Detail:   "Token would not be saved by Artifactory if its expiry is less than the persistency threshold value (default to 10800 seconds) set in Access configuration. See https://www.jfrog.com/confluence/display/JFROG/Access+Tokens#AccessTokens-PersistencyThreshold for details.",
+			}}
 		}
-
-		scopedGroupString := strings.Join(groups[:], ",")
-		scope := "member-of-groups:\"" + scopedGroupString + "\""
-		tokenOptions.Scope = scope
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func unpackAdminToken(d *schema.ResourceData, tokenOptions *AccessTokenOptions) error {
-	if adminToken, ok := d.GetOk("admin_token"); ok {
-		set := adminToken.(*schema.Set)
-		val := set.List()[0].(map[string]interface{})
+func resourceAccessTokenDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return RevokeAccessToken(d.Id(), m)
+}
 
-		instanceID := val["instance_id"].(string)
+// checkAdminTokenInstance keeps the restriction of the deprecated API, which only created admin tokens for the
+// Artifactory instance the token was requested from
+func checkAdminTokenInstance(client *resty.Client, d *schema.ResourceData) error {
+	instanceId := d.Get("admin_token").(*schema.Set).List()[0].(map[string]interface{})["instance_id"].(string)
 
-		scope := "jfrt@" + instanceID + ":admin"
-		tokenOptions.Scope = scope
+	resp, err := client.R().Get(ServiceIdEndpoint)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimPrefix(strings.TrimSpace(resp.String()), "jfrt@") != instanceId {
+		return fmt.Errorf("Admin can create token with admin privileges only on this Artifactory instance: %s", resp.String())
 	}
 
 	return nil
@@ -411,7 +477,3 @@ func getDate(d *schema.ResourceData) (time.Time, int, error) {
 	}
 	return endDate, differenceInSeconds, nil
 }
-
-func TokenOptsToValues(t AccessTokenOptions) (url.Values, error) {
-	return query.Values(t)
-}
@@ -10,12 +10,15 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
 )
 
 const CertificateEndpoint = "artifactory/api/system/security/certificates/"
@@ -51,38 +54,52 @@ func ResourceArtifactoryCertificate() *schema.Resource {
 				Type:      schema.TypeString,
 				Optional:  true,
 				Sensitive: true,
-				ValidateFunc: validation.All(
-					validation.StringIsNotEmpty,
-					func(value interface{}, key string) ([]string, []error) {
-						_, err := extractCertificate(value.(string))
-						if err != nil {
-							return nil, []error{err}
-						}
-						return nil, nil
-					},
-				),
+				ValidateDiagFunc: func(value interface{}, path cty.Path) diag.Diagnostics {
+					if diags := validation.ToDiagFunc(validation.StringIsNotEmpty)(value, path); diags.HasError() {
+						return diags
+					}
+					return validateCertificateContent(value.(string))
+				},
 			},
 			"file": {
 				Type:         schema.TypeString,
 				Sensitive:    true,
 				Optional:     true,
-				ExactlyOneOf: []string{"content", "file"},
-				ValidateFunc: func(value interface{}, key string) ([]string, []error) {
-					var errors []error
+				ExactlyOneOf: []string{"content", "file", "pkcs12_content"},
+				ValidateDiagFunc: func(value interface{}, _ cty.Path) diag.Diagnostics {
 					if _, err := os.Stat(value.(string)); err != nil {
-						return nil, append(errors, err)
+						return diag.FromErr(err)
 					}
 					data, err := ioutil.ReadFile(value.(string))
 					if err != nil {
-						return nil, append(errors, err)
+						return diag.FromErr(err)
 					}
-					_, err = extractCertificate(string(data))
-					if err != nil {
-						return nil, append(errors, err)
-					}
-					return nil, nil
+					return validateCertificateContent(string(data))
 				},
 			},
+			"pkcs12_content": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsBase64,
+				Description:  "Base64 encoded PKCS#12 bundle of the client certificate, its chain and private key. Use `filebase64()` to read a `.p12` or `.pfx` file.",
+			},
+			"pkcs12_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"pkcs12_content"},
+				Description:  "Password of the PKCS#12 bundle.",
+			},
+			"expiry_warning_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          DefaultCertificateExpiryWarningDays,
+				ValidateDiagFunc: validator.IntAtLeast(0),
+				Description: "Number of days before the expiry of the certificate from which a warning is raised when the certificate is refreshed or applied. " +
+					"Set to 0 to disable the warning. Default is 30. The `content` and `file` are validated on their own at plan time, so the warning raised at plan time " +
+					fmt.Sprintf("always uses the default of %d days.", DefaultCertificateExpiryWarningDays),
+			},
 			"fingerprint": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -103,27 +120,138 @@ func ResourceArtifactoryCertificate() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"not_before": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Start of the validity of the certificate, in RFC 3339 format.",
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "End of the validity of the certificate, in RFC 3339 format.",
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Distinguished name of the subject of the certificate.",
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Distinguished name of the issuer of the certificate.",
+			},
 		},
 
 		CustomizeDiff: calculateFingerprint,
 	}
 }
 
+// DefaultCertificateExpiryWarningDays is the default of expiry_warning_days, and the number of days used by the
+// warning raised at plan time
+const DefaultCertificateExpiryWarningDays = 30
+
+// validateCertificateContent fails when the PEM data has no certificate, like the validation of the certificates
+// uploaded before the bundles were verified. The chain and the private key are only verified for pkcs12_content, so a
+// bundle which can't be verified raises a warning. The warning about the expiry of the certificate is raised at plan
+// time from here.
+func validateCertificateContent(pemData string) diag.Diagnostics {
+	if _, err := extractCertificate(pemData); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	if _, err := ParseCertificateBundle(pemData); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "unable to verify the certificate bundle",
+			Detail:   fmt.Sprintf("The certificate is uploaded as is, but Artifactory may not be able to use it: %s", err),
+		})
+	}
+
+	bundle, err := ReadCertificateBundle(pemData)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	leaf := bundle.Leaf()
+
+	return append(diags, certificateExpiryWarning(leaf.Subject.String(), leaf.NotAfter, DefaultCertificateExpiryWarningDays)...)
+}
+
+var certificateDetailsKeys = []string{"fingerprint", "not_before", "not_after", "subject", "issuer"}
+
+func certificateDetails(cert *x509.Certificate) map[string]string {
+	return map[string]string{
+		"not_before": cert.NotBefore.UTC().Format(time.RFC3339),
+		"not_after":  cert.NotAfter.UTC().Format(time.RFC3339),
+		"subject":    cert.Subject.String(),
+		"issuer":     cert.Issuer.String(),
+	}
+}
+
+// calculateFingerprint plans the fingerprint and the details of the certificate from its content
 func calculateFingerprint(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	for _, key := range []string{"content", "file", "pkcs12_content", "pkcs12_password"} {
+		if !d.NewValueKnown(key) {
+			for _, computed := range certificateDetailsKeys {
+				if err := d.SetNewComputed(computed); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
 	content, err := getContentFromDiff(d)
+	if err != nil {
+		return err
+	}
+	bundle, err := ReadCertificateBundle(content)
+	if err != nil {
+		return err
+	}
+
 	fingerprint, err := calculateFingerPrint(content)
 	if err != nil {
 		return err
 	}
 	if d.Get("fingerprint").(string) != fingerprint {
 		if err = d.SetNewComputed("fingerprint"); err != nil {
-			fmt.Println(err)
 			return err
 		}
 	}
+
+	for key, value := range certificateDetails(bundle.Leaf()) {
+		if d.Get(key).(string) != value {
+			if err := d.SetNew(key, value); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
+// certificateExpiryWarning returns a warning when the certificate expires in less than the given number of days
+func certificateExpiryWarning(name string, expiry time.Time, days int) diag.Diagnostics {
+	if days == 0 {
+		return nil
+	}
+
+	remaining := time.Until(expiry)
+	if remaining > time.Duration(days)*24*time.Hour {
+		return nil
+	}
+
+	summary := fmt.Sprintf("certificate %s expires in %d day(s), on %s", name, int(remaining.Hours()/24), expiry.UTC().Format(time.RFC3339))
+	if remaining <= 0 {
+		summary = fmt.Sprintf("certificate %s has expired on %s", name, expiry.UTC().Format(time.RFC3339))
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  summary,
+		Detail:   fmt.Sprintf("Renew the certificate, the warning is raised %d day(s) before the expiry, as set in expiry_warning_days.", days),
+	}}
+}
+
 func formatFingerPrint(f []byte) string {
 	buf := make([]byte, 0, 3*len(f))
 	x := buf[1*len(f) : 3*len(f)]
@@ -208,7 +336,11 @@ func resourceCertificateRead(_ context.Context, d *schema.ResourceData, m interf
 			return diag.Errorf("failed to pack certificate %q", errors)
 		}
 
-		return nil
+		expiry, err := time.Parse(time.RFC3339, (*cert).ValidUntil)
+		if err != nil {
+			return nil
+		}
+		return certificateExpiryWarning(d.Id(), expiry, d.Get("expiry_warning_days").(int))
 	}
 
 	d.SetId("")
@@ -220,6 +352,10 @@ func getContentFromDiff(d *schema.ResourceDiff) (string, error) {
 	content, contentExists := d.GetOkExists("content")
 	file, fileExists := d.GetOkExists("file")
 
+	if pkcs12Content, ok := d.GetOk("pkcs12_content"); ok {
+		return PKCS12ToPEM(pkcs12Content.(string), d.Get("pkcs12_password").(string))
+	}
+
 	if contentExists == fileExists {
 		return "", fmt.Errorf("you must define 'content' as the contents of the pem file, OR set 'file' to the path of your pem file ")
 	}
@@ -249,6 +385,9 @@ func getContentFromData(d *schema.ResourceData) (string, error) {
 		}
 		return string(data), nil
 	}
+	if pkcs12Content, ok := d.GetOk("pkcs12_content"); ok {
+		return PKCS12ToPEM(pkcs12Content.(string), d.Get("pkcs12_password").(string))
+	}
 	return "", fmt.Errorf("mmm, couldn't get content or file. You need either a content or a file")
 }
 
@@ -258,12 +397,27 @@ func resourceCertificateUpdate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	// the chain and the private key of pkcs12_content are verified when it's converted to PEM
+	bundle, err := ReadCertificateBundle(content)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	_, err = m.(util.ProvderMetadata).Client.R().SetBody(content).SetHeader("content-type", "text/plain").Post(CertificateEndpoint + d.Id())
 
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	setValue := util.MkLens(d)
+	var errors []error
+	for key, value := range certificateDetails(bundle.Leaf()) {
+		errors = setValue(key, value)
+	}
+	if len(errors) > 0 {
+		return diag.Errorf("failed to pack certificate %q", errors)
+	}
+
 	return resourceCertificateRead(ctx, d, m)
 }
 
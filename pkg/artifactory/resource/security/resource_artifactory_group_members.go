@@ -0,0 +1,259 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const GroupMembersEndpoint = "access/api/v2/groups/{name}/members"
+
+// GroupMembersPatch adds and removes users of a group without touching the other members
+type GroupMembersPatch struct {
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+type GroupMembers struct {
+	Members []string `json:"members"`
+}
+
+func getGroupMembers(groupName string, m interface{}) (map[string]bool, bool, error) {
+	groupMembers := GroupMembers{}
+	resp, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("name", groupName).
+		SetResult(&groupMembers).
+		Get(GroupMembersEndpoint)
+	if err != nil {
+		if resp != nil && resp.StatusCode() == http.StatusNotFound {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	members := map[string]bool{}
+	for _, member := range groupMembers.Members {
+		members[member] = true
+	}
+	return members, true, nil
+}
+
+func patchGroupMembers(groupName string, add, remove []string, m interface{}) error {
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("name", groupName).
+		SetBody(GroupMembersPatch{
+			Add:    add,
+			Remove: remove,
+		}).
+		Patch(GroupMembersEndpoint)
+	return err
+}
+
+func ResourceArtifactoryGroupMembers() *schema.Resource {
+	var resourceGroupMembersRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		members, found, err := getGroupMembers(d.Id(), m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !found {
+			d.SetId("")
+			return nil
+		}
+
+		// only the users managed by this resource are reported, so members added elsewhere don't cause a diff
+		var users []interface{}
+		for _, userName := range d.Get("users").(*schema.Set).List() {
+			if members[userName.(string)] {
+				users = append(users, userName)
+			}
+		}
+
+		setValue := util.MkLens(d)
+		setValue("group_name", d.Id())
+		errors := setValue("users", schema.NewSet(schema.HashString, users))
+		if errors != nil && len(errors) > 0 {
+			return diag.Errorf("failed to pack group members %q", errors)
+		}
+
+		return nil
+	}
+
+	var resourceGroupMembersCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		groupName := d.Get("group_name").(string)
+
+		if err := patchGroupMembers(groupName, util.CastToStringArr(d.Get("users").(*schema.Set).List()), nil, m); err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(groupName)
+		return resourceGroupMembersRead(ctx, d, m)
+	}
+
+	var resourceGroupMembersUpdate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		oldUsers, newUsers := d.GetChange("users")
+		add := newUsers.(*schema.Set).Difference(oldUsers.(*schema.Set))
+		remove := oldUsers.(*schema.Set).Difference(newUsers.(*schema.Set))
+
+		err := patchGroupMembers(d.Id(), util.CastToStringArr(add.List()), util.CastToStringArr(remove.List()), m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return resourceGroupMembersRead(ctx, d, m)
+	}
+
+	var resourceGroupMembersDelete = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		err := patchGroupMembers(d.Id(), nil, util.CastToStringArr(d.Get("users").(*schema.Set).List()), m)
+		return diag.FromErr(err)
+	}
+
+	// resourceGroupMembersImport takes over all the members of the group, as the users added by the resource can't be
+	// told apart from the other members
+	var resourceGroupMembersImport = func(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		members, found, err := getGroupMembers(d.Id(), m)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("group %s not found", d.Id())
+		}
+
+		var users []interface{}
+		for member := range members {
+			users = append(users, member)
+		}
+
+		setValue := util.MkLens(d)
+		setValue("group_name", d.Id())
+		errors := setValue("users", schema.NewSet(schema.HashString, users))
+		if errors != nil && len(errors) > 0 {
+			return nil, fmt.Errorf("failed to import group members %q", errors)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceGroupMembersCreate,
+		ReadContext:   resourceGroupMembersRead,
+		UpdateContext: resourceGroupMembersUpdate,
+		DeleteContext: resourceGroupMembersDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupMembersImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Name of the group.",
+			},
+			"users": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the users added to the group. The other members of the group are left untouched.",
+			},
+		},
+		Description: "Provides a non-authoritative Artifactory group membership resource. Only the listed users are added to, and removed from, the group.",
+	}
+}
+
+func ResourceArtifactoryGroupMember() *schema.Resource {
+	var parseId = func(id string) (string, string, error) {
+		parts := strings.SplitN(id, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return "", "", fmt.Errorf("unexpected format of ID (%s), expected group_name:user_name", id)
+		}
+		return parts[0], parts[1], nil
+	}
+
+	var resourceGroupMemberRead = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		groupName, userName, err := parseId(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		members, found, err := getGroupMembers(groupName, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if !found || !members[userName] {
+			d.SetId("")
+			return nil
+		}
+
+		setValue := util.MkLens(d)
+		setValue("group_name", groupName)
+		errors := setValue("user_name", userName)
+		if errors != nil && len(errors) > 0 {
+			return diag.Errorf("failed to pack group member %q", errors)
+		}
+
+		return nil
+	}
+
+	var resourceGroupMemberCreate = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		groupName := d.Get("group_name").(string)
+		userName := d.Get("user_name").(string)
+
+		if err := patchGroupMembers(groupName, []string{userName}, nil, m); err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(fmt.Sprintf("%s:%s", groupName, userName))
+		return resourceGroupMemberRead(ctx, d, m)
+	}
+
+	var resourceGroupMemberDelete = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		groupName, userName, err := parseId(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return diag.FromErr(patchGroupMembers(groupName, nil, []string{userName}, m))
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceGroupMemberCreate,
+		ReadContext:   resourceGroupMemberRead,
+		DeleteContext: resourceGroupMemberDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Name of the group.",
+			},
+			"user_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validator.StringIsNotEmpty,
+				Description:      "Name of the user added to the group.",
+			},
+		},
+		Description: "Provides a non-authoritative Artifactory group membership resource for a single user. The other members of the group are left untouched.",
+	}
+}
@@ -0,0 +1,139 @@
+package security_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/security"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const groupMembersTemplate = `
+	resource "artifactory_group" "{{ .groupName }}" {
+		name = "{{ .groupName }}"
+	}
+
+	resource "artifactory_managed_user" "{{ .userName1 }}" {
+		name     = "{{ .userName1 }}"
+		email    = "{{ .userName1 }}@tempurl.org"
+		password = "Passw0rd!123"
+	}
+
+	resource "artifactory_managed_user" "{{ .userName2 }}" {
+		name     = "{{ .userName2 }}"
+		email    = "{{ .userName2 }}@tempurl.org"
+		password = "Passw0rd!123"
+	}
+
+	resource "artifactory_managed_user" "{{ .userName3 }}" {
+		name     = "{{ .userName3 }}"
+		email    = "{{ .userName3 }}@tempurl.org"
+		password = "Passw0rd!123"
+	}
+
+	resource "artifactory_group_members" "{{ .groupName }}" {
+		group_name = artifactory_group.{{ .groupName }}.name
+		users      = [{{ .members }}]
+	}
+
+	resource "artifactory_group_member" "{{ .groupName }}" {
+		group_name = artifactory_group.{{ .groupName }}.name
+		user_name  = artifactory_managed_user.{{ .userName3 }}.name
+	}
+`
+
+func TestAccGroupMembers_nonAuthoritative(t *testing.T) {
+	_, _, groupName := test.MkNames("test-group-members", "artifactory_group")
+	_, _, userName1 := test.MkNames("test-member-", "artifactory_managed_user")
+	_, _, userName2 := test.MkNames("test-member-", "artifactory_managed_user")
+	_, _, userName3 := test.MkNames("test-member-", "artifactory_managed_user")
+	membersFqrn := "artifactory_group_members." + groupName
+	memberFqrn := "artifactory_group_member." + groupName
+
+	testData := map[string]string{
+		"groupName": groupName,
+		"userName1": userName1,
+		"userName2": userName2,
+		"userName3": userName3,
+		"members": fmt.Sprintf("artifactory_managed_user.%s.name, artifactory_managed_user.%s.name",
+			userName1, userName2),
+	}
+	config := util.ExecuteTemplate(groupName, groupMembersTemplate, testData)
+
+	testData["members"] = fmt.Sprintf("artifactory_managed_user.%s.name", userName2)
+	updatedConfig := util.ExecuteTemplate(groupName, groupMembersTemplate, testData)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckGroupDestroy("artifactory_group." + groupName),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(membersFqrn, "group_name", groupName),
+					resource.TestCheckResourceAttr(membersFqrn, "users.#", "2"),
+					resource.TestCheckResourceAttr(memberFqrn, "id", fmt.Sprintf("%s:%s", groupName, userName3)),
+					testAccCheckGroupMembers(groupName, []string{userName1, userName2, userName3}, nil),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(membersFqrn, "users.#", "1"),
+					testAccCheckGroupMembers(groupName, []string{userName2, userName3}, []string{userName1}),
+				),
+			},
+			{
+				ResourceName:      memberFqrn,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// the import takes over all the members of the group
+				ResourceName:  membersFqrn,
+				ImportState:   true,
+				ImportStateId: groupName,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 || states[0].Attributes["users.#"] != "2" {
+						return fmt.Errorf("expected the %s and %s members to be imported, got %v", userName2, userName3, states)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckGroupMembers(groupName string, members, nonMembers []string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		groupMembers := security.GroupMembers{}
+		_, err := acctest.Provider.Meta().(util.ProvderMetadata).Client.R().
+			SetPathParam("name", groupName).
+			SetResult(&groupMembers).
+			Get(security.GroupMembersEndpoint)
+		if err != nil {
+			return err
+		}
+
+		actual := map[string]bool{}
+		for _, member := range groupMembers.Members {
+			actual[member] = true
+		}
+		for _, member := range members {
+			if !actual[member] {
+				return fmt.Errorf("expected user %s to be a member of group %s", member, groupName)
+			}
+		}
+		for _, nonMember := range nonMembers {
+			if actual[nonMember] {
+				return fmt.Errorf("expected user %s not to be a member of group %s", nonMember, groupName)
+			}
+		}
+		return nil
+	}
+}
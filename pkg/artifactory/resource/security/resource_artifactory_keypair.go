@@ -5,11 +5,15 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
@@ -17,6 +21,7 @@ import (
 	"github.com/jfrog/terraform-provider-shared/packer"
 	"github.com/jfrog/terraform-provider-shared/predicate"
 	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
 )
 
 const KeypairEndPoint = "artifactory/api/security/keypair/"
@@ -55,39 +60,139 @@ var keyPairSchema = map[string]*schema.Schema{
 	"private_key": {
 		Type:             schema.TypeString,
 		Sensitive:        true,
-		Required:         true,
+		Optional:         true,
+		Computed:         true,
+		ExactlyOneOf:     []string{"private_key", "generate"},
 		StateFunc:        stripTabs,
 		ValidateDiagFunc: validatePrivateKey,
-		Description:      "Private key. PEM format will be validated.",
-		ForceNew:         true,
+		Description:      "Private key. PEM format will be validated. Conflicts with `generate`.",
 	},
 	"passphrase": {
 		Type:             schema.TypeString,
 		Optional:         true,
-		ForceNew:         true,
 		DiffSuppressFunc: ignoreEmpty,
 		Sensitive:        true,
+		ConflictsWith:    []string{"generate"},
 		Description:      "Passphrase will be used to decrypt the private key. Validated server side",
 	},
 	"public_key": {
 		Type:             schema.TypeString,
-		Required:         true,
+		Optional:         true,
+		Computed:         true,
+		RequiredWith:     []string{"private_key"},
+		ConflictsWith:    []string{"generate"},
 		StateFunc:        stripTabs,
 		ValidateDiagFunc: validatePublicKey,
-		ForceNew:         true,
-		Description:      "Public key. PEM format will be validated.",
+		Description:      "Public key. PEM format will be validated. Exported when the key pair is generated.",
+	},
+	"generate": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Generate the key material of the key pair, of the `pair_type` algorithm, instead of setting `private_key` and `public_key`.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key_size": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					Default:          3072,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntInSlice([]int{2048, 3072, 4096})),
+					Description:      "Size of the RSA key, in bits. Supported sizes - 2048, 3072 and 4096. Default is 3072.",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of the identity of the GPG key.",
+				},
+				"email": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Email of the identity of the GPG key.",
+				},
+				"expires_in": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					ValidateDiagFunc: validator.IntAtLeast(0),
+					Description:      "Validity of the GPG key, in seconds. Default is 0, the key never expires.",
+				},
+				"passphrase": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Passphrase encrypting the private GPG key.",
+				},
+			},
+		},
+	},
+	"fingerprint": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Fingerprint of the public key. For RSA key pairs, the SHA-256 hash of the DER encoded public key.",
 	},
 	"unavailable": {
 		Type:        schema.TypeBool,
 		Computed:    true,
 		Description: "Unknown usage. Returned in the json payload and cannot be set.",
 	},
+	"rotation": {
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Rotate the key pair in place when the key material changes, instead of replacing it.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"grace_period": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					Default:          0,
+					ValidateDiagFunc: validator.IntAtLeast(0),
+					Description: "Time, in seconds, the previous key pair is kept after a rotation. " +
+						"It is deleted by the first apply after the grace period, or by the next rotation. Default is 0.",
+				},
+				"relink_repositories": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  true,
+					Description: "Make the new key pair the primary key pair of the repositories signed with the previous one, " +
+						"and the previous key pair their secondary key pair. Default is `true`.",
+				},
+			},
+		},
+	},
+	"active_pair_name": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the key pair in Artifactory. Equal to `pair_name` until the first rotation, then `pair_name` suffixed with the time of the rotation.",
+	},
+	"previous_pair_name": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Name of the key pair replaced by the last rotation, until it is deleted.",
+	},
+	"previous_pair_delete_after": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time, in RFC 3339 format, after which the previous key pair is deleted.",
+	},
+	"relink_pending": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Set when the repositories couldn't be relinked to the key pair of the last rotation. They are relinked by the next apply.",
+	},
+	"relinked_repositories": {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Description: "Keys of the repositories relinked to the key pair of the last rotation. Repositories managed by Terraform " +
+			"which are listed here don't reference `active_pair_name` and `previous_pair_name`, and have drifted.",
+	},
 }
 
 func ResourceArtifactoryKeyPair() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: createKeyPair,
 		ReadContext:   readKeyPair,
+		UpdateContext: updateKeyPair,
 		DeleteContext: rmKeyPair,
 
 		Importer: &schema.ResourceImporter{
@@ -100,9 +205,108 @@ func ResourceArtifactoryKeyPair() *schema.Resource {
 			" authentication of several package types such as Debian, Opkg, and RPM through the Keys Management UI and REST API.",
 
 		Schema: keyPairSchema,
+		CustomizeDiff: customdiff.All(
+			verifyKeyPairGenerate,
+			rotateKeyPairDiff,
+		),
 	}
 }
 
+func verifyKeyPairGenerate(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Get("pair_type").(string) != "RSA" {
+		return nil
+	}
+
+	for _, key := range []string{"name", "email", "expires_in", "passphrase"} {
+		if _, ok := diff.GetOk("generate.0." + key); ok {
+			return fmt.Errorf("generate.0.%s is only supported for GPG key pairs", key)
+		}
+	}
+
+	return nil
+}
+
+// changedKeyPairMaterial returns the changed attributes holding, or generating, the key material
+func changedKeyPairMaterial(diff *schema.ResourceDiff) []string {
+	var changed []string
+	for _, key := range []string{"private_key", "public_key", "passphrase", "generate"} {
+		if !diff.HasChange(key) {
+			continue
+		}
+		if key == "generate" && len(diff.Get("generate").([]interface{})) > 0 {
+			// the block is kept, so the changed nested attributes are reported for ForceNew to mark them
+			for _, nested := range []string{"key_size", "name", "email", "expires_in", "passphrase"} {
+				if diff.HasChange("generate.0." + nested) {
+					changed = append(changed, "generate.0."+nested)
+				}
+			}
+			if !diff.HasChange("generate.#") {
+				continue
+			}
+		}
+		changed = append(changed, key)
+	}
+	return changed
+}
+
+// rotateKeyPairDiff replaces the key pair when its key material changes, unless the rotation is enabled. The key pair is
+// then rotated in place, and the previous key pair is deleted by the first apply after its grace period.
+func rotateKeyPairDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	// a failed relinking is retried by the next apply
+	if diff.Get("relink_pending").(bool) {
+		if err := diff.SetNew("relink_pending", false); err != nil {
+			return err
+		}
+		if err := diff.SetNewComputed("relinked_repositories"); err != nil {
+			return err
+		}
+	}
+
+	changed := changedKeyPairMaterial(diff)
+	if len(changed) > 0 {
+		if len(diff.Get("rotation").([]interface{})) == 0 {
+			for _, key := range changed {
+				if err := diff.ForceNew(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		computed := []string{"active_pair_name", "previous_pair_name", "previous_pair_delete_after", "relink_pending", "relinked_repositories", "fingerprint"}
+		if diff.HasChange("generate") {
+			computed = append(computed, "private_key", "public_key")
+		}
+		for _, key := range computed {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	deleteAfter := diff.Get("previous_pair_delete_after").(string)
+	if deleteAfter == "" {
+		return nil
+	}
+	expiry, err := time.Parse(time.RFC3339, deleteAfter)
+	if err != nil {
+		return fmt.Errorf("failed to parse previous_pair_delete_after: %s", err)
+	}
+	if time.Now().Before(expiry) {
+		return nil
+	}
+
+	if err := diff.SetNew("previous_pair_name", ""); err != nil {
+		return err
+	}
+	return diff.SetNew("previous_pair_delete_after", "")
+}
+
 func validatePrivateKey(value interface{}, _ cty.Path) diag.Diagnostics {
 	stripped := strings.ReplaceAll(value.(string), "\t", "")
 	var err error
@@ -193,17 +397,56 @@ func unpackKeyPair(s *schema.ResourceData) (interface{}, string, error) {
 		PublicKey:   strings.ReplaceAll(d.GetString("public_key", false), "\t", ""),
 		Unavailable: d.GetBool("unavailable", false),
 	}
+
+	// the passphrase of a generated key pair is set in the generate block
+	if generate := d.Get("generate").([]interface{}); len(generate) > 0 && generate[0] != nil {
+		result.Passphrase = generate[0].(map[string]interface{})["passphrase"].(string)
+	}
+
 	return &result, result.PairName, nil
 }
 
 var keyPairPacker = packer.Universal(
 	predicate.All(
-		predicate.Ignore("private_key", "passphrase"),
+		predicate.Ignore("pair_name", "private_key", "passphrase"),
 		predicate.SchemaHasKey(keyPairSchema),
 	),
 )
 
+func generateKeyPair(d *schema.ResourceData) error {
+	generate := d.Get("generate").([]interface{})
+	if len(generate) == 0 || generate[0] == nil {
+		return nil
+	}
+
+	options := generate[0].(map[string]interface{})
+	keyPair, err := GenerateKeyPair(KeyPairGenerationOptions{
+		PairType:   d.Get("pair_type").(string),
+		KeySize:    options["key_size"].(int),
+		Name:       options["name"].(string),
+		Email:      options["email"].(string),
+		ExpiresIn:  options["expires_in"].(int),
+		Passphrase: options["passphrase"].(string),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate key pair: %s", err)
+	}
+
+	setValue := util.MkLens(d)
+	setValue("private_key", keyPair.PrivateKey)
+	errors := setValue("public_key", keyPair.PublicKey)
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to pack generated key pair %q", errors)
+	}
+
+	return nil
+}
+
 func createKeyPair(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := generateKeyPair(d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	keyPair, key, _ := unpackKeyPair(d)
 
 	_, err := m.(util.ProvderMetadata).Client.R().
@@ -232,10 +475,162 @@ func readKeyPair(_ context.Context, d *schema.ResourceData, meta interface{}) di
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	// a rotated key pair is stored under a new name, so pair_name is only set on import
+	if d.Get("pair_name").(string) == "" {
+		if err := d.Set("pair_name", data.PairName); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("active_pair_name", data.PairName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	fingerprint, err := KeyPairFingerprint(data.PublicKey)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "unable to compute the fingerprint of the public key",
+			Detail:   err.Error(),
+		}}
+	}
+	if err := d.Set("fingerprint", fingerprint); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func keyPairRotation(d *schema.ResourceData) (int, bool) {
+	rotation := d.Get("rotation").([]interface{})
+	if len(rotation) == 0 || rotation[0] == nil {
+		return 0, true
+	}
+
+	options := rotation[0].(map[string]interface{})
+	return options["grace_period"].(int), options["relink_repositories"].(bool)
+}
+
+func deletePreviousKeyPair(ctx context.Context, pairName string, unlink bool, m interface{}) error {
+	if unlink {
+		if _, err := UnlinkKeyPairRepositories(ctx, pairName, m); err != nil {
+			return err
+		}
+	}
+
+	resp, err := m.(util.ProvderMetadata).Client.R().Delete(KeypairEndPoint + pairName)
+	if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+		return fmt.Errorf("failed to delete previous key pair %s: %s", pairName, err)
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("previous key pair %s deleted", pairName))
+	return nil
+}
+
+func updateKeyPair(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	gracePeriod, relink := keyPairRotation(d)
+	rotate := d.HasChanges("private_key", "public_key", "passphrase", "generate")
+	previousPairName, _ := d.GetChange("previous_pair_name")
+
+	// the repositories relinked by the last rotation are kept until the next one
+	relinkedRepositories, _ := d.GetChange("relinked_repositories")
+	if err := d.Set("relinked_repositories", relinkedRepositories); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the repositories which couldn't be relinked by the last rotation are relinked before the previous key pair is
+	// deleted or replaced
+	if relinkPending, _ := d.GetChange("relink_pending"); relinkPending.(bool) && relink && previousPairName.(string) != "" {
+		if diags := relinkKeyPairRepositories(ctx, d, previousPairName.(string), m); diags != nil {
+			return diags
+		}
+	}
+
+	// the previous key pair is deleted once its grace period is over, or when it's replaced by a new rotation
+	if previousPairName.(string) != "" && (rotate || d.Get("previous_pair_name").(string) == "") {
+		if err := deletePreviousKeyPair(ctx, previousPairName.(string), relink, m); err != nil {
+			return diag.FromErr(err)
+		}
+
+		setValue := util.MkLens(d)
+		setValue("previous_pair_name", "")
+		errors := setValue("previous_pair_delete_after", "")
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack previous key pair %q", errors)
+		}
+	}
+
+	if rotate {
+		if err := generateKeyPair(d); err != nil {
+			return diag.FromErr(err)
+		}
+
+		keyPair, _, _ := unpackKeyPair(d)
+		now := time.Now()
+		pairName := fmt.Sprintf("%s-%d", d.Get("pair_name").(string), now.Unix())
+		keyPair.(*KeyPairPayLoad).PairName = pairName
+
+		_, err := m.(util.ProvderMetadata).Client.R().
+			AddRetryCondition(client.RetryOnMergeError).
+			SetBody(keyPair).
+			Post(KeypairEndPoint)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		// the new key pair is saved before the repositories are relinked, so a failed relinking doesn't lose it
+		replacedPairName := d.Id()
+		d.SetId(pairName)
+		setValue := util.MkLens(d)
+		setValue("previous_pair_name", replacedPairName)
+		setValue("relinked_repositories", []string{})
+		errors := setValue("previous_pair_delete_after", now.Add(time.Duration(gracePeriod)*time.Second).UTC().Format(time.RFC3339))
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack previous key pair %q", errors)
+		}
+
+		if relink {
+			if diags := relinkKeyPairRepositories(ctx, d, replacedPairName, m); diags != nil {
+				return diags
+			}
+		}
+	}
+
+	if err := d.Set("relink_pending", false); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return readKeyPair(ctx, d, m)
+}
+
+// relinkKeyPairRepositories relinks the repositories from the previous key pair to the current one, and adds them to
+// relinked_repositories. When it fails, relink_pending is set for the next apply to retry.
+func relinkKeyPairRepositories(ctx context.Context, d *schema.ResourceData, previousPairName string, m interface{}) diag.Diagnostics {
+	relinked, err := RelinkKeyPairRepositories(ctx, previousPairName, d.Id(), m)
+
+	previouslyRelinked := util.CastToStringArr(d.Get("relinked_repositories").([]interface{}))
+	if setErr := d.Set("relinked_repositories", append(previouslyRelinked, relinked...)); setErr != nil {
+		return diag.FromErr(setErr)
+	}
+
+	if err != nil {
+		if setErr := d.Set("relink_pending", true); setErr != nil {
+			return diag.FromErr(setErr)
+		}
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
-func rmKeyPair(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func rmKeyPair(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if previousPairName := d.Get("previous_pair_name").(string); previousPairName != "" {
+		_, relink := keyPairRotation(d)
+		if err := deletePreviousKeyPair(ctx, previousPairName, relink, m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	_, err := m.(util.ProvderMetadata).Client.R().Delete(KeypairEndPoint + d.Id())
 	if err != nil {
 		return diag.FromErr(err)
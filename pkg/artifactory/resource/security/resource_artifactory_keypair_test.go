@@ -1,10 +1,14 @@
 package security_test
 
 import (
+	"encoding/hex"
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/security"
@@ -343,3 +347,233 @@ func TestAccKeyPairGPG(t *testing.T) {
 		},
 	})
 }
+
+func TestAccKeyPairGenerate(t *testing.T) {
+	for _, pairType := range []string{"RSA", "GPG"} {
+		t.Run(pairType, func(t *testing.T) {
+			id, fqrn, name := test.MkNames("mykp", "artifactory_keypair")
+			template := `
+			resource "artifactory_keypair" "{{ .name }}" {
+				pair_name = "{{ .name }}"
+				pair_type = "{{ .pairType }}"
+				alias     = "foo-alias{{ .id }}"
+
+				generate {
+					key_size = 2048
+					{{ if eq .pairType "GPG" }}
+					name       = "Test Signing Key"
+					email      = "signing@tempurl.org"
+					expires_in = 31536000
+					passphrase = "password"
+					{{ end }}
+				}
+			}`
+
+			config := util.ExecuteTemplate(
+				fqrn,
+				template,
+				map[string]string{
+					"id":       fmt.Sprint(id),
+					"name":     name,
+					"pairType": pairType,
+				},
+			)
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:          func() { acctest.PreCheck(t) },
+				ProviderFactories: acctest.ProviderFactories,
+				CheckDestroy:      acctest.VerifyDeleted(fqrn, security.VerifyKeyPair),
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check: resource.ComposeTestCheckFunc(
+							resource.TestCheckResourceAttr(fqrn, "pair_name", name),
+							resource.TestCheckResourceAttr(fqrn, "pair_type", pairType),
+							resource.TestCheckResourceAttrSet(fqrn, "private_key"),
+							resource.TestCheckResourceAttrSet(fqrn, "public_key"),
+							resource.TestCheckResourceAttrSet(fqrn, "fingerprint"),
+						),
+					},
+					{
+						ResourceName:            fqrn,
+						ImportState:             true,
+						ImportStateVerify:       true,
+						ImportStateCheck:        validator.CheckImportState(name, "pair_name"),
+						ImportStateVerifyIgnore: []string{"passphrase", "private_key", "generate"},
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccKeyPairGenerateRSAWithIdentity(t *testing.T) {
+	_, fqrn, name := test.MkNames("mykp", "artifactory_keypair")
+	config := fmt.Sprintf(`
+		resource "artifactory_keypair" "%s" {
+			pair_name = "%s"
+			pair_type = "RSA"
+			alias     = "foo-alias"
+
+			generate {
+				name = "Test Signing Key"
+			}
+		}
+	`, name, name)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.VerifyDeleted(fqrn, security.VerifyKeyPair),
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(".*generate.0.name is only supported for GPG key pairs.*"),
+			},
+		},
+	})
+}
+
+func TestAccKeyPairRotation(t *testing.T) {
+	id, fqrn, name := test.MkNames("mykp", "artifactory_keypair")
+	_, repoFqrn, repoName := test.MkNames("rpm-local", "artifactory_local_rpm_repository")
+	template := `
+		resource "artifactory_keypair" "{{ .name }}" {
+			pair_name = "{{ .name }}"
+			pair_type = "GPG"
+			alias     = "foo-alias{{ .id }}"
+
+			generate {
+				key_size = {{ .keySize }}
+			}
+
+			rotation {
+				grace_period = 0
+			}
+		}
+
+		resource "artifactory_local_rpm_repository" "{{ .repoName }}" {
+			key                   = "{{ .repoName }}"
+			primary_keypair_ref   = artifactory_keypair.{{ .name }}.active_pair_name
+			secondary_keypair_ref = artifactory_keypair.{{ .name }}.previous_pair_name
+		}
+	`
+	testData := map[string]string{
+		"id":       fmt.Sprint(id),
+		"name":     name,
+		"repoName": repoName,
+		"keySize":  "2048",
+	}
+	config := util.ExecuteTemplate(fqrn, template, testData)
+
+	testData["keySize"] = "3072"
+	rotatedConfig := util.ExecuteTemplate(fqrn, template, testData)
+
+	rotatedPairName := regexp.MustCompile(fmt.Sprintf("^%s-[0-9]+$", name))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy: acctest.CompositeCheckDestroy(
+			acctest.VerifyDeleted(repoFqrn, acctest.CheckRepo),
+			acctest.VerifyDeleted(fqrn, security.VerifyKeyPair),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "active_pair_name", name),
+					resource.TestCheckResourceAttr(fqrn, "previous_pair_name", ""),
+					resource.TestCheckResourceAttr(repoFqrn, "primary_keypair_ref", name),
+				),
+			},
+			{
+				// the grace period is over right after the rotation, so the next plan deletes the previous key pair
+				Config:             rotatedConfig,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "pair_name", name),
+					resource.TestMatchResourceAttr(fqrn, "active_pair_name", rotatedPairName),
+					resource.TestCheckResourceAttr(fqrn, "previous_pair_name", name),
+					resource.TestCheckResourceAttrSet(fqrn, "previous_pair_delete_after"),
+					resource.TestCheckResourceAttr(fqrn, "generate.0.key_size", "3072"),
+					resource.TestCheckResourceAttr(fqrn, "relinked_repositories.#", "1"),
+					resource.TestCheckResourceAttr(fqrn, "relinked_repositories.0", repoName),
+					resource.TestMatchResourceAttr(repoFqrn, "primary_keypair_ref", rotatedPairName),
+					resource.TestCheckResourceAttr(repoFqrn, "secondary_keypair_ref", name),
+				),
+			},
+			{
+				Config: rotatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(fqrn, "active_pair_name", rotatedPairName),
+					resource.TestCheckResourceAttr(fqrn, "previous_pair_name", ""),
+					resource.TestCheckResourceAttr(fqrn, "previous_pair_delete_after", ""),
+					resource.TestMatchResourceAttr(repoFqrn, "primary_keypair_ref", rotatedPairName),
+					resource.TestCheckResourceAttr(repoFqrn, "secondary_keypair_ref", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestGenerateKeyPairRSA(t *testing.T) {
+	keyPair, err := security.GenerateKeyPair(security.KeyPairGenerationOptions{PairType: "RSA", KeySize: 2048})
+	if err != nil {
+		t.Fatalf("failed to generate RSA key pair: %s", err)
+	}
+
+	keyPairSchema := security.ResourceArtifactoryKeyPair().Schema
+	if diags := keyPairSchema["private_key"].ValidateDiagFunc(keyPair.PrivateKey, cty.Path{}); diags.HasError() {
+		t.Errorf("invalid private key: %v", diags)
+	}
+	if diags := keyPairSchema["public_key"].ValidateDiagFunc(keyPair.PublicKey, cty.Path{}); diags.HasError() {
+		t.Errorf("invalid public key: %v", diags)
+	}
+
+	fingerprint, err := security.KeyPairFingerprint(keyPair.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to compute fingerprint: %s", err)
+	}
+	if fingerprint != keyPair.Fingerprint || len(fingerprint) != 64 {
+		t.Errorf("unexpected fingerprint %s, expected %s", fingerprint, keyPair.Fingerprint)
+	}
+}
+
+func TestGenerateKeyPairGPG(t *testing.T) {
+	keyPair, err := security.GenerateKeyPair(security.KeyPairGenerationOptions{
+		PairType:   "GPG",
+		KeySize:    2048,
+		Name:       "Test Signing Key",
+		Email:      "signing@tempurl.org",
+		ExpiresIn:  86400,
+		Passphrase: "password",
+	})
+	if err != nil {
+		t.Fatalf("failed to generate GPG key pair: %s", err)
+	}
+
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(keyPair.PrivateKey))
+	if err != nil {
+		t.Fatalf("failed to read private key: %s", err)
+	}
+	entity := entities[0]
+
+	if _, ok := entity.Identities["Test Signing Key <signing@tempurl.org>"]; !ok {
+		t.Errorf("expected identity not found in %v", entity.Identities)
+	}
+	if !entity.PrivateKey.Encrypted {
+		t.Error("expected private key to be encrypted")
+	}
+	if err := entity.PrivateKey.Decrypt([]byte("password")); err != nil {
+		t.Errorf("failed to decrypt private key: %s", err)
+	}
+
+	fingerprint, err := security.KeyPairFingerprint(keyPair.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to compute fingerprint: %s", err)
+	}
+	if fingerprint != keyPair.Fingerprint || fingerprint != strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint)) {
+		t.Errorf("unexpected fingerprint %s, expected %s", fingerprint, keyPair.Fingerprint)
+	}
+}
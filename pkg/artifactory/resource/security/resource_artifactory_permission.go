@@ -0,0 +1,370 @@
+package security
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const (
+	AccessPermissionsEndpoint        = "access/api/v2/permissions"
+	AccessPermissionEndpoint         = "access/api/v2/permissions/{name}"
+	AccessPermissionResourceEndpoint = "access/api/v2/permissions/{name}/{resourceType}"
+)
+
+// Actions of the Access permissions API. Unlike the permission target API, they are upper case, and managing
+// Xray metadata is spelled out.
+const (
+	AccessPermRead            = "READ"
+	AccessPermAnnotate        = "ANNOTATE"
+	AccessPermWrite           = "WRITE"
+	AccessPermDelete          = "DELETE"
+	AccessPermManage          = "MANAGE"
+	AccessPermManageXrayMeta  = "MANAGE_XRAY_METADATA"
+	AccessPermDistribute      = "DISTRIBUTE"
+	AccessPermExecute         = "EXECUTE"
+	AccessPermManageResources = "MANAGE_RESOURCES"
+)
+
+// AccessPermissionResourceTypes are the resource types of the Access permissions API. They are used as is
+// for the attribute names.
+var AccessPermissionResourceTypes = []string{
+	"artifact",
+	"build",
+	"release_bundle",
+	"destination",
+	"pipeline_source",
+}
+
+type AccessPermission struct {
+	Name       string                               `json:"name"`
+	ProjectKey string                               `json:"project_key,omitempty"`
+	Resources  map[string]*AccessPermissionResource `json:"resources"`
+}
+
+type AccessPermissionResource struct {
+	Actions AccessPermissionActions           `json:"actions"`
+	Targets map[string]AccessPermissionTarget `json:"targets"`
+}
+
+type AccessPermissionActions struct {
+	Users  map[string][]string `json:"users"`
+	Groups map[string][]string `json:"groups"`
+}
+
+type AccessPermissionTarget struct {
+	IncludePatterns []string `json:"include_patterns"`
+	ExcludePatterns []string `json:"exclude_patterns"`
+}
+
+func buildAccessPermissionSchema() map[string]*schema.Schema {
+	principalSchema := &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validator.StringIsNotEmpty,
+				},
+				"permissions": {
+					Type: schema.TypeSet,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+						ValidateFunc: validation.StringInSlice([]string{
+							AccessPermRead,
+							AccessPermAnnotate,
+							AccessPermWrite,
+							AccessPermDelete,
+							AccessPermManage,
+							AccessPermManageXrayMeta,
+							AccessPermDistribute,
+							AccessPermExecute,
+							AccessPermManageResources,
+						}, false),
+					},
+					Set:      schema.HashString,
+					Required: true,
+				},
+			},
+		},
+	}
+
+	resourceSchema := &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"targets": {
+					Type:     schema.TypeSet,
+					Required: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: validator.StringIsNotEmpty,
+								Description:      "Name of the target, e.g. a repository key for `artifact`. `ANY LOCAL`, `ANY REMOTE`, `ANY DISTRIBUTION` and `ANY` apply to all the targets of that kind.",
+							},
+							"include_patterns": {
+								Type:        schema.TypeSet,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+								Set:         schema.HashString,
+								Optional:    true,
+								Description: "Patterns of the paths, build names or release bundle names the permission applies to.",
+							},
+							"exclude_patterns": {
+								Type:        schema.TypeSet,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+								Set:         schema.HashString,
+								Optional:    true,
+								Description: "Patterns of the paths, build names or release bundle names excluded from the permission.",
+							},
+						},
+					},
+				},
+				"actions": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"users":  principalSchema,
+							"groups": principalSchema,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	permissionSchema := map[string]*schema.Schema{
+		"name": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validator.StringIsNotEmpty,
+			Description:      "Name of the permission.",
+		},
+		"project_key": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validator.ProjectKey,
+			Description:      "Key of the project the permission is scoped to. When not set, the permission is global.",
+		},
+	}
+
+	for _, resourceType := range AccessPermissionResourceTypes {
+		permissionSchema[resourceType] = resourceSchema
+	}
+
+	return permissionSchema
+}
+
+func ResourceArtifactoryPermission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePermissionCreate,
+		ReadContext:   resourcePermissionRead,
+		UpdateContext: resourcePermissionUpdate,
+		DeleteContext: resourcePermissionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema:      buildAccessPermissionSchema(),
+		Description: "Provides an Artifactory permission resource, managed with the Access permissions API. Supersedes `artifactory_permission_target`, and also covers destinations, pipeline sources and project scoped permissions.",
+	}
+}
+
+func unpackAccessPermissionResource(rawResource interface{}) *AccessPermissionResource {
+	unpackPrincipals := func(rawPrincipals interface{}) map[string][]string {
+		principals := map[string][]string{}
+		for _, rawPrincipal := range rawPrincipals.(*schema.Set).List() {
+			principal := rawPrincipal.(map[string]interface{})
+			principals[principal["name"].(string)] = util.CastToStringArr(principal["permissions"].(*schema.Set).List())
+		}
+		return principals
+	}
+
+	resourceData := rawResource.([]interface{})[0].(map[string]interface{})
+
+	permissionResource := &AccessPermissionResource{
+		Actions: AccessPermissionActions{
+			Users:  map[string][]string{},
+			Groups: map[string][]string{},
+		},
+		Targets: map[string]AccessPermissionTarget{},
+	}
+
+	for _, rawTarget := range resourceData["targets"].(*schema.Set).List() {
+		target := rawTarget.(map[string]interface{})
+		permissionResource.Targets[target["name"].(string)] = AccessPermissionTarget{
+			IncludePatterns: util.CastToStringArr(target["include_patterns"].(*schema.Set).List()),
+			ExcludePatterns: util.CastToStringArr(target["exclude_patterns"].(*schema.Set).List()),
+		}
+	}
+
+	if actions := resourceData["actions"].([]interface{}); len(actions) > 0 && actions[0] != nil {
+		actionsData := actions[0].(map[string]interface{})
+		permissionResource.Actions.Users = unpackPrincipals(actionsData["users"])
+		permissionResource.Actions.Groups = unpackPrincipals(actionsData["groups"])
+	}
+
+	return permissionResource
+}
+
+func unpackAccessPermission(s *schema.ResourceData) *AccessPermission {
+	d := &util.ResourceData{ResourceData: s}
+
+	permission := &AccessPermission{
+		Name:       d.GetString("name", false),
+		ProjectKey: d.GetString("project_key", false),
+		Resources:  map[string]*AccessPermissionResource{},
+	}
+
+	for _, resourceType := range AccessPermissionResourceTypes {
+		if v, ok := s.GetOk(resourceType); ok {
+			permission.Resources[resourceType] = unpackAccessPermissionResource(v)
+		}
+	}
+
+	return permission
+}
+
+func packAccessPermissionResource(permissionResource *AccessPermissionResource) []interface{} {
+	packPrincipals := func(principals map[string][]string) []interface{} {
+		var packed []interface{}
+		for name, permissions := range principals {
+			packed = append(packed, map[string]interface{}{
+				"name":        name,
+				"permissions": schema.NewSet(schema.HashString, util.CastToInterfaceArr(permissions)),
+			})
+		}
+		return packed
+	}
+
+	var targets []interface{}
+	for name, target := range permissionResource.Targets {
+		targets = append(targets, map[string]interface{}{
+			"name":             name,
+			"include_patterns": schema.NewSet(schema.HashString, util.CastToInterfaceArr(target.IncludePatterns)),
+			"exclude_patterns": schema.NewSet(schema.HashString, util.CastToInterfaceArr(target.ExcludePatterns)),
+		})
+	}
+
+	packed := map[string]interface{}{
+		"targets": targets,
+	}
+
+	if len(permissionResource.Actions.Users) > 0 || len(permissionResource.Actions.Groups) > 0 {
+		packed["actions"] = []interface{}{
+			map[string]interface{}{
+				"users":  packPrincipals(permissionResource.Actions.Users),
+				"groups": packPrincipals(permissionResource.Actions.Groups),
+			},
+		}
+	}
+
+	return []interface{}{packed}
+}
+
+func packAccessPermission(permission *AccessPermission, d *schema.ResourceData) diag.Diagnostics {
+	setValue := util.MkLens(d)
+
+	setValue("name", permission.Name)
+	errors := setValue("project_key", permission.ProjectKey)
+	for _, resourceType := range AccessPermissionResourceTypes {
+		var packed []interface{}
+		if permissionResource, ok := permission.Resources[resourceType]; ok && permissionResource != nil {
+			packed = packAccessPermissionResource(permissionResource)
+		}
+		errors = setValue(resourceType, packed)
+	}
+
+	if errors != nil && len(errors) > 0 {
+		return diag.Errorf("failed to pack permission %q", errors)
+	}
+	return nil
+}
+
+func resourcePermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	permission := unpackAccessPermission(d)
+
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetBody(permission).
+		Post(AccessPermissionsEndpoint)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(permission.Name)
+	return resourcePermissionRead(ctx, d, m)
+}
+
+func resourcePermissionRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	permission := &AccessPermission{}
+	resp, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("name", d.Id()).
+		SetResult(permission).
+		Get(AccessPermissionEndpoint)
+	if err != nil {
+		if resp != nil && resp.StatusCode() == http.StatusNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	return packAccessPermission(permission, d)
+}
+
+// resourcePermissionUpdate replaces the resource types that changed one by one, as the Access API has no call to
+// replace a whole permission, and deletes the resource types removed from the configuration.
+func resourcePermissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	permission := unpackAccessPermission(d)
+
+	for _, resourceType := range AccessPermissionResourceTypes {
+		if !d.HasChange(resourceType) {
+			continue
+		}
+
+		req := m.(util.ProvderMetadata).Client.R().
+			SetPathParams(map[string]string{
+				"name":         d.Id(),
+				"resourceType": resourceType,
+			})
+
+		var err error
+		if permissionResource, ok := permission.Resources[resourceType]; ok {
+			_, err = req.SetBody(permissionResource).Put(AccessPermissionResourceEndpoint)
+		} else {
+			_, err = req.Delete(AccessPermissionResourceEndpoint)
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourcePermissionRead(ctx, d, m)
+}
+
+func resourcePermissionDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	resp, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("name", d.Id()).
+		Delete(AccessPermissionEndpoint)
+	if err != nil && resp != nil && resp.StatusCode() == http.StatusNotFound {
+		return nil
+	}
+
+	return diag.FromErr(err)
+}
@@ -0,0 +1,225 @@
+package security_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/security"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const accessPermissionFull = `
+	resource "artifactory_local_generic_repository" "{{ .repo_name }}" {
+		key = "{{ .repo_name }}"
+	}
+
+	resource "artifactory_permission" "{{ .permission_name }}" {
+		name = "{{ .permission_name }}"
+
+		artifact {
+			targets {
+				name             = artifactory_local_generic_repository.{{ .repo_name }}.key
+				include_patterns = ["foo/**"]
+				exclude_patterns = ["bar/**"]
+			}
+
+			actions {
+				users {
+					name        = "anonymous"
+					permissions = ["READ", "WRITE"]
+				}
+
+				groups {
+					name        = "readers"
+					permissions = ["READ"]
+				}
+			}
+		}
+
+		build {
+			targets {
+				name             = "artifactory-build-info"
+				include_patterns = ["**"]
+			}
+
+			actions {
+				users {
+					name        = "anonymous"
+					permissions = ["READ"]
+				}
+			}
+		}
+	}
+`
+
+const accessPermissionUpdate = `
+	resource "artifactory_local_generic_repository" "{{ .repo_name }}" {
+		key = "{{ .repo_name }}"
+	}
+
+	resource "artifactory_permission" "{{ .permission_name }}" {
+		name = "{{ .permission_name }}"
+
+		artifact {
+			targets {
+				name             = artifactory_local_generic_repository.{{ .repo_name }}.key
+				include_patterns = ["**"]
+			}
+
+			actions {
+				groups {
+					name        = "readers"
+					permissions = ["READ", "ANNOTATE"]
+				}
+			}
+		}
+
+		release_bundle {
+			targets {
+				name             = "release-bundles"
+				include_patterns = ["**"]
+			}
+
+			actions {
+				groups {
+					name        = "readers"
+					permissions = ["READ"]
+				}
+			}
+		}
+	}
+`
+
+func TestAccPermission_full(t *testing.T) {
+	_, permFqrn, permName := test.MkNames("test-perm", "artifactory_permission")
+	_, _, repoName := test.MkNames("test-perm-repo", "artifactory_local_generic_repository")
+
+	tempStruct := map[string]string{
+		"repo_name":       repoName,
+		"permission_name": permName,
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testPermissionCheckDestroy(permFqrn),
+		Steps: []resource.TestStep{
+			{
+				Config: util.ExecuteTemplate(permFqrn, accessPermissionFull, tempStruct),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(permFqrn, "name", permName),
+					resource.TestCheckResourceAttr(permFqrn, "artifact.0.targets.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(permFqrn, "artifact.0.targets.*", map[string]string{
+						"name":               repoName,
+						"include_patterns.#": "1",
+						"exclude_patterns.#": "1",
+					}),
+					resource.TestCheckResourceAttr(permFqrn, "artifact.0.actions.0.users.#", "1"),
+					resource.TestCheckResourceAttr(permFqrn, "artifact.0.actions.0.groups.#", "1"),
+					resource.TestCheckResourceAttr(permFqrn, "build.0.targets.#", "1"),
+					resource.TestCheckResourceAttr(permFqrn, "build.0.actions.0.users.#", "1"),
+					resource.TestCheckResourceAttr(permFqrn, "release_bundle.#", "0"),
+				),
+			},
+			{
+				Config: util.ExecuteTemplate(permFqrn, accessPermissionUpdate, tempStruct),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(permFqrn, "artifact.0.actions.0.users.#", "0"),
+					resource.TestCheckTypeSetElemNestedAttrs(permFqrn, "artifact.0.actions.0.groups.*", map[string]string{
+						"name":          "readers",
+						"permissions.#": "2",
+					}),
+					resource.TestCheckResourceAttr(permFqrn, "build.#", "0"),
+					resource.TestCheckResourceAttr(permFqrn, "release_bundle.0.targets.#", "1"),
+					resource.TestCheckResourceAttr(permFqrn, "release_bundle.0.actions.0.groups.#", "1"),
+				),
+			},
+			{
+				ResourceName:      permFqrn,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateCheck:  validator.CheckImportState(permName, "name"),
+			},
+		},
+	})
+}
+
+// Permission targets are permissions of the Access API, so they can be imported as artifactory_permission
+func TestAccPermission_importPermissionTarget(t *testing.T) {
+	_, permFqrn, permName := test.MkNames("test-perm", "artifactory_permission")
+
+	tempStruct := map[string]string{
+		"repo_name":       "example-repo-local",
+		"permission_name": permName,
+	}
+
+	permissionTarget := util.ExecuteTemplate(permFqrn, permissionNoIncludes, tempStruct)
+	permission := util.ExecuteTemplate(permFqrn, `
+	resource "artifactory_permission" "{{ .permission_name }}" {
+		name = "{{ .permission_name }}"
+	}
+`, tempStruct)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testPermissionTargetCheckDestroy("artifactory_permission_target." + permName),
+		Steps: []resource.TestStep{
+			{
+				Config: permissionTarget,
+			},
+			{
+				Config:        permissionTarget + permission,
+				ResourceName:  permFqrn,
+				ImportState:   true,
+				ImportStateId: permName,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported permission, got %d", len(states))
+					}
+					attributes := states[0].Attributes
+					expected := map[string]string{
+						"name":                         permName,
+						"artifact.0.targets.#":         "1",
+						"artifact.0.actions.0.users.#": "1",
+					}
+					for key, value := range expected {
+						if attributes[key] != value {
+							return fmt.Errorf("expected %s to be %s, got %s", key, value, attributes[key])
+						}
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testPermissionCheckDestroy(id ...string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		for _, fqrn := range id {
+			rs, ok := s.RootModule().Resources[fqrn]
+			if !ok {
+				return fmt.Errorf("err: Resource id[%s] not found", id)
+			}
+
+			resp, err := acctest.Provider.Meta().(util.ProvderMetadata).Client.R().
+				SetPathParam("name", rs.Primary.ID).
+				Get(security.AccessPermissionEndpoint)
+			if err != nil && resp != nil && resp.StatusCode() == http.StatusNotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("error: Permission %s still exists", rs.Primary.ID)
+		}
+		return nil
+	}
+}
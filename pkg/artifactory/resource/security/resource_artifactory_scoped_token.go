@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -17,13 +18,16 @@ import (
 	"github.com/jfrog/terraform-provider-shared/validator"
 )
 
+const AccessTokensEndpoint = "access/api/v1/tokens"
+
 type AccessTokenPostResponse struct {
-	TokenId      string `json:"token_id"`
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
-	ExpiresIn    int    `json:"expires_in"`
-	Scope        string `json:"scope"`
-	TokenType    string `json:"token_type"`
+	TokenId        string `json:"token_id"`
+	AccessToken    string `json:"access_token"`
+	RefreshToken   string `json:"refresh_token"`
+	ExpiresIn      int    `json:"expires_in"`
+	Scope          string `json:"scope"`
+	TokenType      string `json:"token_type"`
+	ReferenceToken string `json:"reference_token"`
 }
 
 type AccessTokenErrorResponse struct {
@@ -36,16 +40,43 @@ func (a AccessTokenPostResponse) Id() string {
 	return a.TokenId
 }
 
+// IsTokenRotationDue reports whether a token issued at issuedAt and expiring at expiry, both in seconds since the
+// epoch, has to be rotated at now. A zero expiry means the token never expires, and a zero rotateBeforeExpiry or
+// rotationPeriod disables the corresponding rule.
+func IsTokenRotationDue(issuedAt, expiry, rotateBeforeExpiry, rotationPeriod int, now time.Time) bool {
+	if rotateBeforeExpiry > 0 && expiry > 0 && now.Unix() >= int64(expiry-rotateBeforeExpiry) {
+		return true
+	}
+
+	if rotationPeriod > 0 && issuedAt > 0 && now.Unix() >= int64(issuedAt+rotationPeriod) {
+		return true
+	}
+
+	return false
+}
+
+// Scopes of the applied-permissions grammar, e.g. `applied-permissions/groups:readers,"release managers"`,
+// `applied-permissions/roles:myproj:Developer` or `system:metrics:r`
+var (
+	scopeGroupsRegex   = regexp.MustCompile(`^applied-permissions/groups:("[^"]+"|[^",]+)(,("[^"]+"|[^",]+))*$`)
+	scopeRolesRegex    = regexp.MustCompile(`^applied-permissions/roles:[a-z][a-z0-9]{1,31}:("[^"]+"|[^",]+)(,("[^"]+"|[^",]+))*$`)
+	scopeSystemRegex   = regexp.MustCompile(`^system:[a-z]+:(\*|[rwdam](,[rwdam])*)$`)
+	scopeArtifactRegex = regexp.MustCompile(`^artifact:[^:]+:(\*|[rwdam](,[rwdam])*)$`)
+)
+
 func ResourceArtifactoryScopedToken() *schema.Resource {
 
 	type AccessTokenPostRequest struct {
-		GrantType   string `json:"grant_type"`
-		Username    string `json:"username,omitempty"`
-		Scope       string `json:"scope,omitempty"`
-		ExpiresIn   int    `json:"expires_in"`
-		Refreshable bool   `json:"refreshable"`
-		Description string `json:"description"`
-		Audience    string `json:"audience,omitempty"`
+		GrantType             string `json:"grant_type"`
+		Username              string `json:"username,omitempty"`
+		ProjectKey            string `json:"project_key,omitempty"`
+		Scope                 string `json:"scope,omitempty"`
+		ExpiresIn             int    `json:"expires_in"`
+		Refreshable           bool   `json:"refreshable"`
+		Description           string `json:"description"`
+		Audience              string `json:"audience,omitempty"`
+		IncludeReferenceToken bool   `json:"include_reference_token"`
+		ForceRevocable        bool   `json:"force_revocable,omitempty"`
 	}
 
 	type AccessTokenGet struct {
@@ -99,17 +130,23 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 							[]string{
 								"applied-permissions/user",
 								"applied-permissions/admin",
-								"system:metrics:r",
-								"system:livelogs:r",
 							},
 							true,
 						),
 						validation.StringMatch(
-							regexp.MustCompile(`^applied-permissions/groups:.+$`),
+							scopeGroupsRegex,
 							"must be 'applied-permissions/groups:<group-name>[,<group-name>...]'",
 						),
 						validation.StringMatch(
-							regexp.MustCompile(`^artifact:.+:([rwdam*]|([rwdam]+(,[rwdam]+)))$`),
+							scopeRolesRegex,
+							"must be 'applied-permissions/roles:<project-key>:<role-name>[,<role-name>...]'",
+						),
+						validation.StringMatch(
+							scopeSystemRegex,
+							"must be 'system:<resource>:<actions>'",
+						),
+						validation.StringMatch(
+							scopeArtifactRegex,
 							"must be '<resource-type>:<target>[/<sub-resource>]:<actions>'",
 						),
 					),
@@ -125,6 +162,8 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 				"* `applied-permissions/admin` - the scope assigned to admin users." +
 				"* `applied-permissions/groups` - the group to which permissions are assigned by group name " +
 				"(use username to inicate the group name)" +
+				"* `applied-permissions/roles:<project-key>:<role-name>` - the project roles the permissions are assigned by, " +
+				"for project scoped tokens" +
 				"* `system:metrics:r` - for getting the service metrics" +
 				"* `system:livelogs:r` - for getting the service livelogsr" +
 				"The scope to assign to the token should be provided as a list of scope tokens, limited to 500 characters in total.\n" +
@@ -157,6 +196,37 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 				"configuration in 'access.config.yaml'. See [API documentation](https://www.jfrog.com/confluence/display/JFROG/Artifactory+REST+API#ArtifactoryRESTAPI-RevokeTokenbyIDrevoketokenbyid) for details. " +
 				"Token would not be saved by Artifactory if this is less than the persistency threshold value (default to 10800 seconds) set in Access configuration. See https://www.jfrog.com/confluence/display/JFROG/Access+Tokens#AccessTokens-PersistencyThreshold for details.",
 		},
+		"project_key": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validator.ProjectKey,
+			Description: "The project for which this token is created. Project admins can create tokens for their " +
+				"project, limited to the `applied-permissions/roles:<project-key>:<role-name>` scopes of the project.",
+		},
+		"grant_type": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "client_credentials",
+			ForceNew:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"client_credentials"}, false)),
+			Description: "The grant type used to authenticate the request. The only value accepted by Access to create a token is " +
+				"`client_credentials`, which is the default. Tokens are refreshed by the rotation of this resource instead of the `refresh_token` grant type.",
+		},
+		"include_reference_token": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			ForceNew:    true,
+			Description: "Also create a reference token, a short alias of the access token exported in `reference_token`. Default is `false`.",
+		},
+		"force_revocable": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			ForceNew:    true,
+			Description: "Make the token revocable even when `expires_in` is below the persistency threshold of Access. Requires Artifactory 7.50.3 or later. Default is `false`.",
+		},
 		"refreshable": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -184,11 +254,42 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 				"Default to '*@*' if not set. Service ID must begin with valid JFrog service type. " +
 				"Options: jfrt, jfxr, jfpip, jfds, jfmc, jfac, jfevt, jfmd, jfcon, or *",
 		},
+		"rotate_before_expiry": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validator.IntAtLeast(0),
+			Description: "When set, the token is rotated by the first apply run less than this amount of time, in seconds, " +
+				"before it expires. The previous token stays valid until the next rotation, and is exported in `previous_access_token`.",
+		},
+		"rotation_period": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validator.IntAtLeast(0),
+			Description: "When set, the token is rotated by the first apply run this amount of time, in seconds, " +
+				"after it was issued. The previous token stays valid until the next rotation, and is exported in `previous_access_token`.",
+		},
 		"access_token": {
 			Type:      schema.TypeString,
 			Computed:  true,
 			Sensitive: true,
 		},
+		"reference_token": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The reference token, when `include_reference_token` is set.",
+		},
+		"previous_access_token": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The token replaced by the last rotation. It is revoked by the next rotation, or when the resource is destroyed.",
+		},
+		"previous_token_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the token replaced by the last rotation.",
+		},
 		"refresh_token": {
 			Type:      schema.TypeString,
 			Computed:  true,
@@ -232,12 +333,16 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 		}
 
 		accessToken := AccessTokenPostRequest{
-			Username:    d.GetString("username", false),
-			Scope:       scopesString,
-			ExpiresIn:   d.GetInt("expires_in", false),
-			Refreshable: d.GetBool("refreshable", false),
-			Description: d.GetString("description", false),
-			Audience:    audiencesString,
+			GrantType:             d.GetString("grant_type", false),
+			Username:              d.GetString("username", false),
+			ProjectKey:            d.GetString("project_key", false),
+			Scope:                 scopesString,
+			ExpiresIn:             d.GetInt("expires_in", false),
+			Refreshable:           d.GetBool("refreshable", false),
+			Description:           d.GetString("description", false),
+			Audience:              audiencesString,
+			IncludeReferenceToken: d.GetBool("include_reference_token", false),
+			ForceRevocable:        d.GetBool("force_revocable", false),
 		}
 
 		return &accessToken, nil
@@ -251,7 +356,7 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 		resp, err := m.(util.ProvderMetadata).Client.R().
 			SetPathParam("id", id).
 			SetResult(&accessToken).
-			Get("access/api/v1/tokens/{id}")
+			Get(AccessTokensEndpoint + "/{id}")
 
 		if err != nil {
 			if resp != nil && resp.StatusCode() == http.StatusNotFound {
@@ -283,6 +388,11 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 			setValue("refresh_token", accessToken.RefreshToken)
 		}
 
+		// only have reference token if 'include_reference_token' is set to true in the request
+		if len(accessToken.ReferenceToken) > 0 {
+			setValue("reference_token", accessToken.ReferenceToken)
+		}
+
 		errors := setValue("token_type", accessToken.TokenType)
 
 		if len(errors) > 0 {
@@ -298,13 +408,11 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 			return diag.FromErr(err)
 		}
 
-		accessToken.GrantType = "client_credentials"
-
 		result := AccessTokenPostResponse{}
 		_, err = m.(util.ProvderMetadata).Client.R().
 			SetBody(accessToken).
 			SetResult(&result).
-			Post("access/api/v1/tokens")
+			Post(AccessTokensEndpoint)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -319,21 +427,67 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 		return accessTokenRead(ctx, data, m)
 	}
 
-	var accessTokenDelete = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
-		respError := AccessTokenErrorResponse{}
-		id := data.Id()
+	// accessTokenUpdate rotates the token when it is due. The new token is created before the current one is
+	// revoked, so consumers can switch to it while `previous_access_token` is still valid. The new token is saved
+	// before the token replaced by the previous rotation is revoked, so a failed revocation doesn't lose it.
+	var accessTokenUpdate = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		d := &util.ResourceData{ResourceData: data}
+		if !IsTokenRotationDue(d.GetInt("issued_at", false), d.GetInt("expiry", false), d.GetInt("rotate_before_expiry", false), d.GetInt("rotation_period", false), time.Now()) {
+			return accessTokenRead(ctx, data, m)
+		}
 
-		_, err := m.(util.ProvderMetadata).Client.R().
-			SetPathParam("id", id).
-			SetError(&respError).
-			Delete("access/api/v1/tokens/{id}")
+		accessToken, err := unpackAccessTokenPostRequest(data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
+		result := AccessTokenPostResponse{}
+		_, err = m.(util.ProvderMetadata).Client.R().
+			SetBody(accessToken).
+			SetResult(&result).
+			Post(AccessTokensEndpoint)
 		if err != nil {
-			return diag.Diagnostics{{
-				Severity: diag.Error,
-				Summary:  fmt.Sprintf("Failed to revoke scoped token %s", id),
-				Detail:   respError.Detail,
-			}}
+			return diag.FromErr(err)
+		}
+
+		replacedTokenId := d.GetString("previous_token_id", false)
+		previousTokenId := data.Id()
+		previousAccessToken := d.GetString("access_token", false)
+		data.SetId(result.Id())
+
+		setValue := util.MkLens(data)
+		setValue("previous_token_id", previousTokenId)
+		if errors := setValue("previous_access_token", previousAccessToken); len(errors) > 0 {
+			return diag.Errorf("failed to pack previous access token %q", errors)
+		}
+
+		diags := packAccessTokenPostResponse(data, result)
+		if diags != nil {
+			return diags
+		}
+
+		if replacedTokenId != "" {
+			if revokeDiags := RevokeAccessToken(replacedTokenId, m); revokeDiags.HasError() {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Failed to revoke token %s replaced by the previous rotation", replacedTokenId),
+					Detail:   fmt.Sprintf("The token was rotated, but token %s is still valid until it expires or is revoked: %s", replacedTokenId, revokeDiags[0].Detail),
+				})
+			}
+		}
+
+		return append(diags, accessTokenRead(ctx, data, m)...)
+	}
+
+	var accessTokenDelete = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if previousTokenId := data.Get("previous_token_id").(string); previousTokenId != "" {
+			if diags := RevokeAccessToken(previousTokenId, m); diags != nil {
+				return diags
+			}
+		}
+
+		if diags := RevokeAccessToken(data.Id(), m); diags != nil {
+			return diags
 		}
 
 		data.SetId("")
@@ -341,12 +495,49 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 		return nil
 	}
 
+	var rotationDiff = func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		rotateBeforeExpiry := diff.Get("rotate_before_expiry").(int)
+		expiresIn := diff.Get("expires_in").(int)
+		if rotateBeforeExpiry > 0 && expiresIn > 0 && rotateBeforeExpiry >= expiresIn {
+			return fmt.Errorf("rotate_before_expiry (%d) must be less than expires_in (%d)", rotateBeforeExpiry, expiresIn)
+		}
+
+		if diff.Id() == "" {
+			return nil
+		}
+
+		if !IsTokenRotationDue(diff.Get("issued_at").(int), diff.Get("expiry").(int), rotateBeforeExpiry, diff.Get("rotation_period").(int), time.Now()) {
+			return nil
+		}
+
+		for _, key := range []string{"access_token", "refresh_token", "reference_token", "previous_access_token", "previous_token_id", "expiry", "issued_at"} {
+			if err := diff.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
 	return &schema.Resource{
 		CreateContext: accessTokenCreate,
 		ReadContext:   accessTokenRead,
+		UpdateContext: accessTokenUpdate,
 		DeleteContext: accessTokenDelete,
 
-		Schema: scopedTokenSchema,
+		Schema:        scopedTokenSchema,
+		CustomizeDiff: rotationDiff,
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				// The v0 attributes are a subset of the current schema
+				Type:    (&schema.Resource{Schema: scopedTokenSchema}).CoreConfigSchema().ImpliedType(),
+				Upgrade: ResourceScopedTokenStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
 		Description: "Create scoped tokens for any of the services in your JFrog Platform and to " +
 			"manage user access to these services. If left at the default setting, the token will " +
 			"be created with the user-identity scope, which allows users to identify themselves in " +
@@ -354,6 +545,43 @@ func ResourceArtifactoryScopedToken() *schema.Resource {
 	}
 }
 
+// ResourceScopedTokenStateUpgradeV0 sets the defaults of `grant_type`, `include_reference_token` and
+// `force_revocable`, which tokens created before these attributes were added don't have in their state. These
+// attributes force a new token, and are not returned by Access, so without the defaults every existing token would
+// be replaced.
+func ResourceScopedTokenStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	for _, key := range []string{"include_reference_token", "force_revocable"} {
+		if rawState[key] == nil {
+			rawState[key] = false
+		}
+	}
+	if rawState["grant_type"] == nil {
+		rawState["grant_type"] = "client_credentials"
+	}
+
+	return rawState, nil
+}
+
+// RevokeAccessToken revokes the token with the given ID. Tokens already revoked, or never persisted by Access, are ignored.
+func RevokeAccessToken(id string, m interface{}) diag.Diagnostics {
+	respError := AccessTokenErrorResponse{}
+
+	resp, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("id", id).
+		SetError(&respError).
+		Delete(AccessTokensEndpoint + "/{id}")
+
+	if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Failed to revoke token %s", id),
+			Detail:   respError.Detail,
+		}}
+	}
+
+	return nil
+}
+
 func CheckAccessToken(id string, request *resty.Request) (*resty.Response, error) {
-	return request.SetPathParam("id", id).Get("access/api/v1/tokens/{id}")
+	return request.SetPathParam("id", id).Get(AccessTokensEndpoint + "/{id}")
 }
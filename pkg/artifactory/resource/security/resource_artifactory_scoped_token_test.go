@@ -1,13 +1,24 @@
 package security_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/security"
+	"github.com/jfrog/terraform-provider-shared/client"
 	"github.com/jfrog/terraform-provider-shared/test"
 	"github.com/jfrog/terraform-provider-shared/util"
 )
@@ -440,3 +451,293 @@ func TestAccScopedToken_WithExpiresInSetToZeroForNonExpiringToken(t *testing.T)
 		},
 	})
 }
+
+func TestAccScopedToken_Rotation(t *testing.T) {
+	_, fqrn, name := test.MkNames("test-access-token", "artifactory_scoped_token")
+
+	accessTokenConfig := util.ExecuteTemplate(
+		"TestAccScopedToken",
+		`resource "artifactory_user" "test-user" {
+			name              = "testuser"
+		    email             = "testuser@tempurl.org"
+			admin             = true
+			disable_ui_access = false
+			groups            = ["readers"]
+			password          = "Passw0rd!"
+		}
+
+		resource "artifactory_scoped_token" "{{ .name }}" {
+			username        = artifactory_user.test-user.name
+			description     = "test description"
+			rotation_period = 1
+		}`,
+		map[string]interface{}{
+			"name": name,
+		},
+	)
+
+	var firstTokenId string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.VerifyDeleted(fqrn, security.CheckAccessToken),
+		Steps: []resource.TestStep{
+			{
+				Config: accessTokenConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(fqrn, "access_token"),
+					resource.TestCheckResourceAttr(fqrn, "previous_access_token", ""),
+					func(s *terraform.State) error {
+						firstTokenId = s.RootModule().Resources[fqrn].Primary.ID
+						return nil
+					},
+				),
+				// the token is due for rotation a second after it is issued
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: accessTokenConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(fqrn, "access_token"),
+					resource.TestCheckResourceAttrSet(fqrn, "previous_access_token"),
+					resource.TestCheckResourceAttrPtr(fqrn, "previous_token_id", &firstTokenId),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccScopedToken_WithRotateBeforeExpiryNotLessThanExpiresIn(t *testing.T) {
+	accessTokenConfig := `
+		resource "artifactory_scoped_token" "test" {
+			username             = "testuser"
+			expires_in           = 86400
+			rotate_before_expiry = 86400
+		}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      accessTokenConfig,
+				ExpectError: regexp.MustCompile(`rotate_before_expiry \(86400\) must be less than expires_in \(86400\)`),
+			},
+		},
+	})
+}
+
+func TestIsTokenRotationDue(t *testing.T) {
+	now := time.Unix(1000000, 0)
+
+	testCases := []struct {
+		description        string
+		issuedAt           int
+		expiry             int
+		rotateBeforeExpiry int
+		rotationPeriod     int
+		due                bool
+	}{
+		{description: "no rotation", issuedAt: 900000, expiry: 1000100, due: false},
+		{description: "before expiry window", issuedAt: 900000, expiry: 1000100, rotateBeforeExpiry: 50, due: false},
+		{description: "in expiry window", issuedAt: 900000, expiry: 1000100, rotateBeforeExpiry: 100, due: true},
+		{description: "non expiring token", issuedAt: 900000, expiry: 0, rotateBeforeExpiry: 100, due: false},
+		{description: "before rotation period", issuedAt: 900000, expiry: 0, rotationPeriod: 200000, due: false},
+		{description: "after rotation period", issuedAt: 900000, expiry: 0, rotationPeriod: 100000, due: true},
+	}
+
+	for _, testCase := range testCases {
+		actual := security.IsTokenRotationDue(testCase.issuedAt, testCase.expiry, testCase.rotateBeforeExpiry, testCase.rotationPeriod, now)
+		if actual != testCase.due {
+			t.Errorf("%s: expected %t, got %t", testCase.description, testCase.due, actual)
+		}
+	}
+}
+
+func TestAccScopedToken_WithReferenceToken(t *testing.T) {
+	_, fqrn, name := test.MkNames("test-access-token", "artifactory_scoped_token")
+
+	accessTokenConfig := util.ExecuteTemplate(
+		"TestAccScopedToken",
+		`resource "artifactory_user" "test-user" {
+			name              = "testuser"
+		    email             = "testuser@tempurl.org"
+			admin             = true
+			disable_ui_access = false
+			groups            = ["readers"]
+			password          = "Passw0rd!"
+		}
+
+		resource "artifactory_scoped_token" "{{ .name }}" {
+			username                = artifactory_user.test-user.name
+			scopes                  = ["applied-permissions/groups:readers"]
+			include_reference_token = true
+			force_revocable         = true
+		}`,
+		map[string]interface{}{
+			"name": name,
+		},
+	)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.VerifyDeleted(fqrn, security.CheckAccessToken),
+		Steps: []resource.TestStep{
+			{
+				Config: accessTokenConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "grant_type", "client_credentials"),
+					resource.TestCheckResourceAttr(fqrn, "include_reference_token", "true"),
+					resource.TestCheckResourceAttr(fqrn, "force_revocable", "true"),
+					resource.TestCheckResourceAttrSet(fqrn, "access_token"),
+					resource.TestCheckResourceAttrSet(fqrn, "reference_token"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccScopedToken_WithInvalidProjectKey(t *testing.T) {
+	accessTokenConfig := `
+		resource "artifactory_scoped_token" "test" {
+			project_key = "Invalid-Key"
+			scopes      = ["applied-permissions/roles:myproj:Developer"]
+		}`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      accessTokenConfig,
+				ExpectError: regexp.MustCompile(`.*project_key.*`),
+			},
+		},
+	})
+}
+
+func TestScopedTokenScopesValidation(t *testing.T) {
+	validateScope := security.ResourceArtifactoryScopedToken().Schema["scopes"].Elem.(*schema.Schema).ValidateDiagFunc
+
+	testCases := []struct {
+		scope string
+		valid bool
+	}{
+		{scope: "applied-permissions/user", valid: true},
+		{scope: "applied-permissions/admin", valid: true},
+		{scope: "applied-permissions/groups:readers", valid: true},
+		{scope: `applied-permissions/groups:readers,"release managers"`, valid: true},
+		{scope: "applied-permissions/groups:", valid: false},
+		{scope: "applied-permissions/groups:readers,", valid: false},
+		{scope: "applied-permissions/roles:myproj:Developer", valid: true},
+		{scope: `applied-permissions/roles:myproj:Developer,"Release Manager"`, valid: true},
+		{scope: "applied-permissions/roles:Developer", valid: false},
+		{scope: "system:metrics:r", valid: true},
+		{scope: "system:livelogs:r", valid: true},
+		{scope: "system:identities:r,w", valid: true},
+		{scope: "system:metrics", valid: false},
+		{scope: "system:metrics:x", valid: false},
+		{scope: "artifact:generic-local:r", valid: true},
+		{scope: "artifact:generic-local/path:*", valid: true},
+		{scope: "artifact:generic-local:r,w,d", valid: true},
+		{scope: "artifact:generic-local:rw", valid: false},
+		{scope: "foo", valid: false},
+	}
+
+	for _, testCase := range testCases {
+		diags := validateScope(testCase.scope, cty.Path{})
+		if diags.HasError() == testCase.valid {
+			t.Errorf("scope %q: expected valid to be %t, got %v", testCase.scope, testCase.valid, diags)
+		}
+	}
+}
+
+func TestScopedTokenStateUpgradeV0(t *testing.T) {
+	v0State := map[string]interface{}{
+		"id":           "5a5d6e59-0d4c-4a7e-8b4b-6b4a2b7f9e3c",
+		"username":     "existinguser",
+		"scopes":       []interface{}{"applied-permissions/user"},
+		"refreshable":  false,
+		"access_token": "fake-token",
+	}
+	v1State := map[string]interface{}{
+		"id":                      "5a5d6e59-0d4c-4a7e-8b4b-6b4a2b7f9e3c",
+		"username":                "existinguser",
+		"scopes":                  []interface{}{"applied-permissions/user"},
+		"refreshable":             false,
+		"access_token":            "fake-token",
+		"include_reference_token": false,
+		"force_revocable":         false,
+		"grant_type":              "client_credentials",
+	}
+
+	actual, err := security.ResourceScopedTokenStateUpgradeV0(context.Background(), v0State, nil)
+	if err != nil {
+		t.Fatalf("error migrating state: %s", err)
+	}
+
+	if !reflect.DeepEqual(v1State, actual) {
+		t.Fatalf("expected: %v\n\ngot: %v", v1State, actual)
+	}
+}
+
+func TestScopedTokenRotationWithFailedRevocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/access/api/v1/tokens":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"token_id": "new-id", "access_token": "new-token", "scope": "applied-permissions/user", "token_type": "Bearer"})
+		case r.Method == http.MethodGet && r.URL.Path == "/access/api/v1/tokens/new-id":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"token_id": "new-id", "issued_at": time.Now().Unix()})
+		case r.Method == http.MethodDelete && r.URL.Path == "/access/api/v1/tokens/replaced-id":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":[{"code":"FORBIDDEN","message":"Forbidden"}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenResource := security.ResourceArtifactoryScopedToken()
+	d := schema.TestResourceDataRaw(t, tokenResource.Schema, map[string]interface{}{
+		"rotation_period": 1,
+	})
+	d.SetId("current-id")
+	for key, value := range map[string]interface{}{"access_token": "current-token", "previous_token_id": "replaced-id", "issued_at": 1} {
+		if err := d.Set(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	diags := tokenResource.UpdateContext(context.Background(), d, util.ProvderMetadata{Client: restyClient})
+	if diags.HasError() {
+		t.Fatalf("expected the failed revocation to be a warning, got: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning for the failed revocation, got: %v", diags)
+	}
+
+	if d.Id() != "new-id" {
+		t.Errorf("expected the new token to be saved, got id %q", d.Id())
+	}
+	expected := map[string]string{
+		"access_token":          "new-token",
+		"previous_token_id":     "current-id",
+		"previous_access_token": "current-token",
+	}
+	for key, value := range expected {
+		if actual := d.Get(key); actual != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, actual)
+		}
+	}
+}
@@ -2,14 +2,37 @@ package user
 
 import (
 	"context"
+	"encoding/xml"
+	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
 
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/configuration"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/security"
 	"github.com/jfrog/terraform-provider-shared/util"
 )
 
+const (
+	AnonymousUserName             = "anonymous"
+	AnonymousPermissionTargetName = "anonymous-access"
+	// BuildInfoRepository is the repository of the build info, targeted by the build section of the permission targets
+	BuildInfoRepository = "artifactory-build-info"
+)
+
+type AnonymousAccessSettings struct {
+	AnonAccessEnabled              bool `xml:"anonAccessEnabled" yaml:"anonAccessEnabled"`
+	AnonAccessToBuildInfosDisabled bool `xml:"anonAccessToBuildInfosDisabled" yaml:"anonAccessToBuildInfosDisabled"`
+}
+
+type XmlAnonymousAccessConfig struct {
+	XMLName  xml.Name                `xml:"config" yaml:"-"`
+	Security AnonymousAccessSettings `xml:"security" yaml:"security"`
+}
+
 func ResourceArtifactoryAnonymousUser() *schema.Resource {
 
 	type AnonymousUser struct {
@@ -24,6 +47,62 @@ func ResourceArtifactoryAnonymousUser() *schema.Resource {
 			Computed:    true,
 			Description: "Username for anonymous user. This should not be set in the HCL, or change after importing into Terraform state.",
 		},
+		"enable_anonymous_access": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+			Description: "Allow the anonymous user to access Artifactory, with the permissions granted to it. The current " +
+				"setting is kept when not set. Don't set it together with `enable_anonymous_access` of `artifactory_general_security`.",
+		},
+		"build_info_access": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Computed: true,
+			Description: "Allow the anonymous user to access the build info, i.e. the metadata of the builds and their " +
+				"artifacts, with the permissions granted to it. The current setting is kept when not set.",
+		},
+		"permission_target_name": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          AnonymousPermissionTargetName,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			Description: "Name of the permission target granting read access to the anonymous user. Default is `anonymous-access`. " +
+				"An existing permission target which wasn't created by Terraform isn't replaced.",
+		},
+		"permission_target_created": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the permission target was created by Terraform, and is deleted with the resource.",
+		},
+		"repositories": {
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Set:         schema.HashString,
+			Optional:    true,
+			Description: "Repositories the anonymous user can read. Can include `ANY`, `ANY LOCAL`, `ANY REMOTE` and `ANY DISTRIBUTION`.",
+		},
+		"includes_pattern": {
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Set:         schema.HashString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Paths of the repositories the anonymous user can read. Default is `**`.",
+		},
+		"excludes_pattern": {
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Set:         schema.HashString,
+			Optional:    true,
+			Description: "Paths of the repositories the anonymous user can't read.",
+		},
+		"build_includes_pattern": {
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Set:         schema.HashString,
+			Optional:    true,
+			Description: "Build patterns, e.g. `my-app/**`, of the builds the anonymous user can read.",
+		},
 	}
 
 	packAnonymousUser := func(user AnonymousUser, d *schema.ResourceData) diag.Diagnostics {
@@ -54,7 +133,11 @@ func ResourceArtifactoryAnonymousUser() *schema.Resource {
 			return diag.FromErr(err)
 		}
 
-		return packAnonymousUser(*user, rd)
+		if diags := packAnonymousUser(*user, rd); diags.HasError() {
+			return diags
+		}
+
+		return packAnonymousAccess(rd, m)
 	}
 
 	resourceAnonymousUserCreate := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -62,11 +145,58 @@ func ResourceArtifactoryAnonymousUser() *schema.Resource {
 	}
 
 	resourceAnonymousUserUpdate := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		return diag.Errorf("Anonymous Artifactory user cannot be updated. Use `terraform import` instead.")
+		if d.HasChanges("enable_anonymous_access", "build_info_access") {
+			content, err := yaml.Marshal(&XmlAnonymousAccessConfig{
+				Security: AnonymousAccessSettings{
+					AnonAccessEnabled:              d.Get("enable_anonymous_access").(bool),
+					AnonAccessToBuildInfosDisabled: !d.Get("build_info_access").(bool),
+				},
+			})
+			if err != nil {
+				return diag.Errorf("failed to marshal anonymous access settings during Update")
+			}
+
+			err = configuration.SendConfigurationPatch(content, m)
+			if err != nil {
+				return diag.Errorf("failed to send PATCH request to Artifactory during Update")
+			}
+		}
+
+		if err := applyAnonymousPermissionTarget(d, m); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return resourceAnonymousUserRead(ctx, d, m)
 	}
 
+	// the anonymous user and the anonymous access settings are kept, only the permission target created by Terraform
+	// is deleted
 	resourceAnonymousUserDelete := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		return diag.Errorf("Anonymous Artifactory user cannot be deleted. Use `terraform state rm` instead.")
+		if d.Get("permission_target_created").(bool) {
+			if err := deletePermissionTarget(d.Get("permission_target_name").(string), m); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		d.SetId("")
+		return nil
+	}
+
+	importAnonymousUser := func(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+		if err := d.Set("permission_target_name", AnonymousPermissionTargetName); err != nil {
+			return nil, err
+		}
+		if err := d.Set("permission_target_created", false); err != nil {
+			return nil, err
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+
+	resourceAnonymousUserV0 := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": anonymousUserSchema["name"],
+		},
 	}
 
 	return &schema.Resource{
@@ -76,11 +206,160 @@ func ResourceArtifactoryAnonymousUser() *schema.Resource {
 		DeleteContext: resourceAnonymousUserDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importAnonymousUser,
 		},
 
 		Schema: anonymousUserSchema,
 
-		Description: "Provides an Artifactory anonymous user resource. This can be used to import Artifactory 'anonymous' uer for some use cases where this is useful.\n\nThis resource is not intended for managing the 'anonymous' user in Artifactory. Use the `resource_artifactory_user` resource instead.\n\n!> Anonymous user cannot be created from scratch, nor updated/deleted once imported into Terraform state.",
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceAnonymousUserV0.CoreConfigSchema().ImpliedType(),
+				Upgrade: ResourceAnonymousUserStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
+		Description: "Provides an Artifactory anonymous user resource. Once imported, it enables the anonymous access, and grants the anonymous user read access to repositories and builds with a permission target.\n\n!> Anonymous user cannot be created from scratch. Destroying the resource only deletes the permission target.",
+	}
+}
+
+// ResourceAnonymousUserStateUpgradeV0 sets the permission target name of the anonymous users imported before the
+// permission target was managed. Their permission target isn't created by Terraform.
+func ResourceAnonymousUserStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if name, _ := rawState["permission_target_name"].(string); name == "" {
+		rawState["permission_target_name"] = AnonymousPermissionTargetName
+	}
+	rawState["permission_target_created"] = false
+
+	return rawState, nil
+}
+
+func packAnonymousAccess(d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	config := XmlAnonymousAccessConfig{}
+	_, err := m.(util.ProvderMetadata).Client.R().SetResult(&config).Get("artifactory/api/system/configuration")
+	if err != nil {
+		return diag.Errorf("failed to retrieve data from API: /artifactory/api/system/configuration during Read")
+	}
+
+	setValue := util.MkLens(d)
+	setValue("enable_anonymous_access", config.Security.AnonAccessEnabled)
+	setValue("build_info_access", !config.Security.AnonAccessToBuildInfosDisabled)
+
+	// a permission target which wasn't created by Terraform isn't read, so it isn't replaced by the next apply
+	permissionTarget := security.PermissionTargetParams{}
+	if d.Get("permission_target_created").(bool) {
+		name := d.Get("permission_target_name").(string)
+		resp, err := m.(util.ProvderMetadata).Client.R().
+			SetResult(&permissionTarget).
+			Get(security.PermissionsEndPoint + name)
+		if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+			return diag.FromErr(err)
+		}
+	}
+
+	// a permission target deleted outside of Terraform leaves the anonymous user without access, which is planned
+	// to be granted again
+	var repositories, includesPattern, excludesPattern, buildIncludesPattern []string
+	if permissionTarget.Repo != nil {
+		repositories = permissionTarget.Repo.Repositories
+		includesPattern = permissionTarget.Repo.IncludePatterns
+		excludesPattern = permissionTarget.Repo.ExcludePatterns
+	}
+	if permissionTarget.Build != nil {
+		buildIncludesPattern = permissionTarget.Build.IncludePatterns
+	}
+
+	setValue("repositories", schema.NewSet(schema.HashString, util.CastToInterfaceArr(repositories)))
+	setValue("includes_pattern", schema.NewSet(schema.HashString, util.CastToInterfaceArr(includesPattern)))
+	setValue("excludes_pattern", schema.NewSet(schema.HashString, util.CastToInterfaceArr(excludesPattern)))
+	errors := setValue("build_includes_pattern", schema.NewSet(schema.HashString, util.CastToInterfaceArr(buildIncludesPattern)))
+
+	if errors != nil && len(errors) > 0 {
+		return diag.Errorf("failed to pack anonymous access %q", errors)
+	}
+
+	return nil
+}
+
+func anonymousReadSection(repositories, includesPattern, excludesPattern []string) *security.PermissionTargetSection {
+	if len(includesPattern) == 0 {
+		includesPattern = []string{"**"}
+	}
+
+	return &security.PermissionTargetSection{
+		IncludePatterns: includesPattern,
+		ExcludePatterns: excludesPattern,
+		Repositories:    repositories,
+		Actions: &security.Actions{
+			Users: map[string][]string{AnonymousUserName: {security.PermRead}},
+		},
+	}
+}
+
+func deletePermissionTarget(name string, m interface{}) error {
+	resp, err := m.(util.ProvderMetadata).Client.R().Delete(security.PermissionsEndPoint + name)
+	if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+		return err
+	}
+	return nil
+}
+
+// applyAnonymousPermissionTarget replaces the permission target granting read access to the anonymous user, or
+// deletes it when no repository nor build is granted. Only the permission targets created by Terraform are replaced
+// or deleted: an existing permission target with the same name is reported as an error instead.
+func applyAnonymousPermissionTarget(d *schema.ResourceData, m interface{}) error {
+	rd := &util.ResourceData{ResourceData: d}
+	oldName, newName := d.GetChange("permission_target_name")
+	name := newName.(string)
+	created := rd.GetBool("permission_target_created", false)
+	repositories := rd.GetSet("repositories")
+	buildIncludesPattern := rd.GetSet("build_includes_pattern")
+
+	if created && oldName.(string) != name {
+		if err := deletePermissionTarget(oldName.(string), m); err != nil {
+			return err
+		}
+		if err := d.Set("permission_target_created", false); err != nil {
+			return err
+		}
+		created = false
 	}
+
+	if len(repositories) == 0 && len(buildIncludesPattern) == 0 {
+		if created {
+			if err := deletePermissionTarget(name, m); err != nil {
+				return err
+			}
+		}
+		return d.Set("permission_target_created", false)
+	}
+
+	permissionTarget := security.PermissionTargetParams{Name: name}
+	if len(repositories) > 0 {
+		permissionTarget.Repo = anonymousReadSection(repositories, rd.GetSet("includes_pattern"), rd.GetSet("excludes_pattern"))
+	}
+	if len(buildIncludesPattern) > 0 {
+		permissionTarget.Build = anonymousReadSection([]string{BuildInfoRepository}, buildIncludesPattern, nil)
+	}
+
+	exists, err := security.PermTargetExists(name, m)
+	if err != nil {
+		return err
+	}
+	if exists && !created {
+		return fmt.Errorf("permission target %s already exists, and wasn't created by Terraform. Set another permission_target_name", name)
+	}
+
+	req := m.(util.ProvderMetadata).Client.R().SetBody(permissionTarget)
+	if exists {
+		_, err = req.Put(security.PermissionsEndPoint + name)
+	} else {
+		_, err = req.Post(security.PermissionsEndPoint + name)
+	}
+	if err != nil {
+		return err
+	}
+
+	return d.Set("permission_target_created", true)
 }
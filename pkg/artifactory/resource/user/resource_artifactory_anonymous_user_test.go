@@ -1,11 +1,25 @@
 package user_test
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/security"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/user"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/util"
 	"github.com/jfrog/terraform-provider-shared/validator"
 )
 
@@ -54,3 +68,186 @@ func TestAccAnonymousUserNotCreateable(t *testing.T) {
 		},
 	})
 }
+
+// anonymousAccessStandIn answers the users, system configuration and permission targets APIs the way Artifactory does,
+// for an instance with anonymous access disabled
+func anonymousAccessStandIn(t *testing.T) (util.ProvderMetadata, *string, map[string]security.PermissionTargetParams) {
+	var lock sync.Mutex
+	configPatch := ""
+	permissionTargets := map[string]security.PermissionTargetParams{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		switch {
+		case r.URL.Path == "/"+user.UsersEndpointPath+user.AnonymousUserName:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"name":"anonymous"}`))
+		case r.URL.Path == "/artifactory/api/system/configuration" && r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			configPatch = string(body)
+		case r.URL.Path == "/artifactory/api/system/configuration":
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<config><security><anonAccessEnabled>false</anonAccessEnabled></security></config>`))
+		case strings.HasPrefix(r.URL.Path, "/"+security.PermissionsEndPoint):
+			name := strings.TrimPrefix(r.URL.Path, "/"+security.PermissionsEndPoint)
+			permissionTarget, exists := permissionTargets[name]
+			switch r.Method {
+			case http.MethodPost, http.MethodPut:
+				if exists == (r.Method == http.MethodPost) {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+				if err := json.NewDecoder(r.Body).Decode(&permissionTarget); err != nil {
+					t.Fatal(err)
+				}
+				permissionTargets[name] = permissionTarget
+			case http.MethodDelete:
+				delete(permissionTargets, name)
+			default:
+				if !exists {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(permissionTarget)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return util.ProvderMetadata{Client: restyClient}, &configPatch, permissionTargets
+}
+
+func TestAnonymousUserAccess(t *testing.T) {
+	m, configPatch, permissionTargets := anonymousAccessStandIn(t)
+	anonymous := user.ResourceArtifactoryAnonymousUser()
+
+	d := schema.TestResourceDataRaw(t, anonymous.Schema, map[string]interface{}{
+		"enable_anonymous_access": true,
+		"build_info_access":       false,
+		"repositories":            []interface{}{"docs-local", "ANY REMOTE"},
+		"excludes_pattern":        []interface{}{"internal/**"},
+		"build_includes_pattern":  []interface{}{"docs-site/**"},
+	})
+	d.SetId(user.AnonymousUserName)
+
+	if diags := anonymous.UpdateContext(context.Background(), d, m); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	if !strings.Contains(*configPatch, "anonAccessEnabled: true") || !strings.Contains(*configPatch, "anonAccessToBuildInfosDisabled: true") {
+		t.Errorf("expected anonymous access to be enabled without build info, got: %s", *configPatch)
+	}
+
+	permissionTarget, ok := permissionTargets["anonymous-access"]
+	if !ok {
+		t.Fatalf("expected the anonymous-access permission target to be created, got: %v", permissionTargets)
+	}
+	if permissionTarget.Repo == nil || len(permissionTarget.Repo.Repositories) != 2 ||
+		permissionTarget.Repo.IncludePatterns[0] != "**" || permissionTarget.Repo.ExcludePatterns[0] != "internal/**" ||
+		permissionTarget.Repo.Actions.Users["anonymous"][0] != security.PermRead {
+		t.Errorf("expected read access to the repositories, got: %+v", permissionTarget.Repo)
+	}
+	if permissionTarget.Build == nil || permissionTarget.Build.Repositories[0] != user.BuildInfoRepository ||
+		permissionTarget.Build.IncludePatterns[0] != "docs-site/**" {
+		t.Errorf("expected read access to the builds, got: %+v", permissionTarget.Build)
+	}
+
+	if diags := anonymous.DeleteContext(context.Background(), d, m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(permissionTargets) != 0 {
+		t.Errorf("expected the permission target to be deleted, got: %v", permissionTargets)
+	}
+}
+
+func TestAnonymousUserAccess_existingPermissionTarget(t *testing.T) {
+	m, _, permissionTargets := anonymousAccessStandIn(t)
+	anonymous := user.ResourceArtifactoryAnonymousUser()
+	existing := security.PermissionTargetParams{Name: user.AnonymousPermissionTargetName}
+	permissionTargets[user.AnonymousPermissionTargetName] = existing
+
+	d := schema.TestResourceDataRaw(t, anonymous.Schema, map[string]interface{}{
+		"repositories": []interface{}{"docs-local"},
+	})
+	d.SetId(user.AnonymousUserName)
+
+	diags := anonymous.UpdateContext(context.Background(), d, m)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "wasn't created by Terraform") {
+		t.Errorf("expected the existing permission target not to be replaced, got: %v", diags)
+	}
+	if permissionTargets[user.AnonymousPermissionTargetName].Repo != nil {
+		t.Errorf("expected the existing permission target to be kept, got: %+v", permissionTargets[user.AnonymousPermissionTargetName])
+	}
+
+	if diags := anonymous.DeleteContext(context.Background(), d, m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if _, ok := permissionTargets[user.AnonymousPermissionTargetName]; !ok {
+		t.Error("expected the existing permission target not to be deleted")
+	}
+}
+
+func TestAnonymousUserAccess_renamePermissionTarget(t *testing.T) {
+	m, _, permissionTargets := anonymousAccessStandIn(t)
+	anonymous := user.ResourceArtifactoryAnonymousUser()
+
+	state := anonymous.Data(&terraform.InstanceState{
+		ID: user.AnonymousUserName,
+		Attributes: map[string]string{
+			"permission_target_name":    user.AnonymousPermissionTargetName,
+			"permission_target_created": "true",
+		},
+	}).State()
+	permissionTargets[user.AnonymousPermissionTargetName] = security.PermissionTargetParams{Name: user.AnonymousPermissionTargetName}
+
+	diff, err := anonymous.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"permission_target_name": "public-docs",
+		"repositories":           []interface{}{"docs-local"},
+	}), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.RequiresNew() {
+		t.Error("expected the permission target to be renamed without replacing the anonymous user")
+	}
+
+	if _, diags := anonymous.Apply(context.Background(), state, diff, m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if _, ok := permissionTargets[user.AnonymousPermissionTargetName]; ok {
+		t.Errorf("expected the previous permission target to be deleted, got: %v", permissionTargets)
+	}
+	if _, ok := permissionTargets["public-docs"]; !ok {
+		t.Errorf("expected the public-docs permission target to be created, got: %v", permissionTargets)
+	}
+}
+
+func TestAnonymousUserStateUpgradeV0(t *testing.T) {
+	actual, err := user.ResourceAnonymousUserStateUpgradeV0(context.Background(), map[string]interface{}{
+		"id":   user.AnonymousUserName,
+		"name": user.AnonymousUserName,
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"id":                        user.AnonymousUserName,
+		"name":                      user.AnonymousUserName,
+		"permission_target_name":    user.AnonymousPermissionTargetName,
+		"permission_target_created": false,
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %v\n\ngot: %v", expected, actual)
+	}
+}
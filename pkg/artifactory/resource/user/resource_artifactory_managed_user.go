@@ -2,13 +2,22 @@ package user
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"golang.org/x/exp/maps"
+
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/util"
 )
 
+// EncryptedPasswordEndpoint is used to verify the password of a user, as it accepts clear-text passwords even when
+// the password encryption policy requires encrypted passwords
+const EncryptedPasswordEndpoint = "artifactory/api/security/encryptedPassword"
+
 func ResourceArtifactoryManagedUser() *schema.Resource {
 	managedUserSchema := map[string]*schema.Schema{
 		"password": {
@@ -18,21 +27,31 @@ func ResourceArtifactoryManagedUser() *schema.Resource {
 			ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
 			Description:      "Password for the user.",
 		},
+		"verify_password": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: "When enabled, the password is verified on each refresh by authenticating as the user, and an update " +
+				"is planned to reset it when it no longer works, e.g. after the user changed it in the UI. Each failed " +
+				"verification counts as a failed login attempt of the user. Default is `false`.",
+		},
 	}
 	maps.Copy(managedUserSchema, baseUserSchema)
 
 	return &schema.Resource{
 		CreateContext: resourceManagedUserCreate,
-		ReadContext:   resourceUserRead,
+		ReadContext:   resourceManagedUserRead,
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importManagedUser,
 		},
 
 		Schema: managedUserSchema,
 
+		CustomizeDiff: unlockUserDiff,
+
 		Description: "Provides an Artifactory managed user resource. This can be used to create and manage Artifactory users. For example, service account where password is known and managed externally.",
 	}
 }
@@ -40,3 +59,63 @@ func ResourceArtifactoryManagedUser() *schema.Resource {
 func resourceManagedUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return resourceBaseUserCreate(ctx, d, m, nil)
 }
+
+func resourceManagedUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := resourceUserRead(ctx, d, m)
+	if diags.HasError() || d.Id() == "" || !d.Get("verify_password").(bool) {
+		return diags
+	}
+
+	// a locked, disabled or expired user is refused whatever the password, and the internal password is not used
+	// when it is disabled, so the password can't be verified
+	password := d.Get("password").(string)
+	if password == "" || d.Get("locked").(bool) || d.Get("disabled").(bool) || d.Get("password_expired").(bool) ||
+		d.Get("internal_password_disabled").(bool) {
+		return diags
+	}
+
+	valid, err := VerifyUserPassword(d.Get("name").(string), password, m)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	// the password is cleared from the state to plan its reset. It's not verified again until then, so the drift
+	// only counts as a single failed login attempt.
+	if !valid {
+		if err := d.Set("password", ""); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
+
+// VerifyUserPassword authenticates as the user to check that the password is still the password of the user
+func VerifyUserPassword(userName, password string, m interface{}) (bool, error) {
+	// the provider client authenticates with its own token, which takes precedence over basic authentication
+	restyClient, err := client.Build(m.(util.ProvderMetadata).Client.BaseURL, "")
+	if err != nil {
+		return false, err
+	}
+
+	// each retry would count as another failed login attempt of the user
+	resp, err := restyClient.SetRetryCount(0).R().
+		SetBasicAuth(userName, password).
+		Get(EncryptedPasswordEndpoint)
+	if err != nil {
+		if resp != nil && resp.StatusCode() == http.StatusUnauthorized {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to verify the password of user %s: %s", userName, err)
+	}
+
+	return true, nil
+}
+
+func importManagedUser(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("verify_password", false); err != nil {
+		return nil, err
+	}
+
+	return importUser(ctx, d, m)
+}
@@ -3,11 +3,14 @@ package user_test
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/user"
+	"github.com/jfrog/terraform-provider-shared/client"
 	"github.com/jfrog/terraform-provider-shared/test"
 	"github.com/jfrog/terraform-provider-shared/util"
 	"github.com/jfrog/terraform-provider-shared/validator"
@@ -140,6 +143,175 @@ func TestAccManagedUser(t *testing.T) {
 	})
 }
 
+func TestAccManagedUser_Lifecycle(t *testing.T) {
+	const userLifecycle = `
+		resource "artifactory_managed_user" "%s" {
+			name             = "%s"
+			email            = "dummy%d@a.com"
+			password         = "Passsw0rd!"
+			disabled         = %t
+			password_expired = %t
+			unlock_on_apply  = true
+		}
+	`
+	id, fqrn, name := test.MkNames("foobar-", "artifactory_managed_user")
+	username := fmt.Sprintf("dummy_user%d", id)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckManagedUserDestroy(fqrn),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(userLifecycle, name, username, id, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "disabled", "false"),
+					resource.TestCheckResourceAttr(fqrn, "password_expired", "false"),
+					resource.TestCheckResourceAttr(fqrn, "locked", "false"),
+					testAccCheckUserDisabled(username, false),
+				),
+			},
+			{
+				Config: fmt.Sprintf(userLifecycle, name, username, id, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "disabled", "true"),
+					resource.TestCheckResourceAttr(fqrn, "password_expired", "true"),
+					testAccCheckUserDisabled(username, true),
+				),
+			},
+			{
+				Config: fmt.Sprintf(userLifecycle, name, username, id, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "disabled", "false"),
+					resource.TestCheckResourceAttr(fqrn, "password_expired", "false"),
+					testAccCheckUserDisabled(username, false),
+				),
+			},
+			{
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateCheck:        validator.CheckImportState(username, "name"),
+				ImportStateVerifyIgnore: []string{"password", "unlock_on_apply"},
+			},
+		},
+	})
+}
+
+func TestAccManagedUser_PasswordDrift(t *testing.T) {
+	const userVerifyPassword = `
+		resource "artifactory_managed_user" "%s" {
+			name            = "%s"
+			email           = "dummy%d@a.com"
+			password        = "Passsw0rd!"
+			verify_password = true
+		}
+	`
+	id, fqrn, name := test.MkNames("foobar-", "artifactory_managed_user")
+	username := fmt.Sprintf("dummy_user%d", id)
+	config := fmt.Sprintf(userVerifyPassword, name, username, id)
+
+	changePassword := func() {
+		restyClient := acctest.GetTestResty(t)
+		_, err := restyClient.R().
+			SetBody(map[string]interface{}{"name": username, "email": fmt.Sprintf("dummy%d@a.com", id), "password": "Ch4ngedInTheUI!"}).
+			Post(user.UsersEndpointPath + username)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckManagedUserDestroy(fqrn),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "verify_password", "true"),
+					testAccCheckUserPassword(username, "Passsw0rd!", true),
+				),
+			},
+			{
+				PreConfig:          changePassword,
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  testAccCheckUserPassword(username, "Passsw0rd!", true),
+			},
+			{
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateCheck:        validator.CheckImportState(username, "name"),
+				ImportStateVerifyIgnore: []string{"password", "unlock_on_apply", "verify_password"},
+			},
+		},
+	})
+}
+
+func testAccCheckUserPassword(userName, password string, expected bool) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		valid, err := user.VerifyUserPassword(userName, password, acctest.Provider.Meta())
+		if err != nil {
+			return err
+		}
+		if valid != expected {
+			return fmt.Errorf("expected password of user %s to be valid: %t, got %t", userName, expected, valid)
+		}
+		return nil
+	}
+}
+
+func TestVerifyUserPassword(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userName, password, ok := r.BasicAuth()
+		if r.URL.Path != "/"+user.EncryptedPasswordEndpoint || r.Header.Get("Authorization") == "Bearer token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !ok || userName != "jdoe" || password != "Passsw0rd!" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("AP7eCk6M8xU3ZDyGkm6Y8FJbQ8L"))
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the password must be verified without the credentials of the provider
+	m := util.ProvderMetadata{Client: restyClient.SetAuthToken("token")}
+
+	valid, err := user.VerifyUserPassword("jdoe", "Passsw0rd!", m)
+	if err != nil || !valid {
+		t.Errorf("expected the password to be valid, got: %t, %v", valid, err)
+	}
+
+	valid, err = user.VerifyUserPassword("jdoe", "Ch4ngedInTheUI!", m)
+	if err != nil || valid {
+		t.Errorf("expected the password to be invalid, got: %t, %v", valid, err)
+	}
+}
+
+func testAccCheckUserDisabled(userName string, expected bool) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		disabled, err := user.IsUserDisabled(userName, acctest.Provider.Meta())
+		if err != nil {
+			return err
+		}
+		if disabled != expected {
+			return fmt.Errorf("expected user %s to be disabled: %t, got %t", userName, expected, disabled)
+		}
+		return nil
+	}
+}
+
 func testAccCheckManagedUserDestroy(id string) func(*terraform.State) error {
 	return func(s *terraform.State) error {
 		client := acctest.Provider.Meta().(util.ProvderMetadata).Client
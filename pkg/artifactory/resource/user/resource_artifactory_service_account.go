@@ -0,0 +1,379 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sethvargo/go-password/password"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/security"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+type serviceAccountTokenRequest struct {
+	GrantType   string `json:"grant_type"`
+	Username    string `json:"username"`
+	Scope       string `json:"scope,omitempty"`
+	ExpiresIn   int    `json:"expires_in"`
+	Description string `json:"description"`
+}
+
+type serviceAccountToken struct {
+	TokenId  string `json:"token_id"`
+	Expiry   int    `json:"expiry"`
+	IssuedAt int    `json:"issued_at"`
+}
+
+// the token attributes changed by a rotation
+var serviceAccountTokenKeys = []string{"access_token", "token_id", "previous_access_token", "previous_token_id", "expiry", "issued_at"}
+
+func ResourceArtifactoryServiceAccount() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServiceAccountCreate,
+		ReadContext:   resourceServiceAccountRead,
+		UpdateContext: resourceServiceAccountUpdate,
+		DeleteContext: resourceServiceAccountDelete,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+				Description:      "Username of the service account.",
+			},
+			"email": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validator.IsEmail,
+				Description:      "Email of the service account, e.g. the address of the team owning it.",
+			},
+			"groups": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "Groups the service account is a member of. When not set, the service account is not a member of any group.",
+			},
+			"scopes": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Scopes of the token, as for `artifactory_scoped_token`. Default is `applied-permissions/user`, the permissions of the service account and its groups. A change rotates the token.",
+			},
+			"expires_in": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validator.IntAtLeast(0),
+				Description: "Time, in seconds, after which the token expires. 0 for a token which never expires. Default is based on the " +
+					"Access configuration. Tokens expiring before the persistency threshold of Access (10800 seconds by default) " +
+					"are not persisted, and can't be rotated or revoked. A change rotates the token.",
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(0, 1024)),
+				Description:      "Description of the token. A change rotates the token.",
+			},
+			"rotate_before_expiry": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validator.IntAtLeast(0),
+				Description: "When set, the token is rotated by the first apply run less than this amount of time, in seconds, " +
+					"before it expires.",
+			},
+			"rotation_period": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validator.IntAtLeast(0),
+				Description:      "When set, the token is rotated by the first apply run this amount of time, in seconds, after it was issued.",
+			},
+			"access_token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token of the service account.",
+			},
+			"token_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the token.",
+			},
+			"previous_access_token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token replaced by the last rotation. It is revoked by the next rotation, or when the resource is destroyed.",
+			},
+			"previous_token_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the token replaced by the last rotation.",
+			},
+			"expiry": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Expiry of the token, in seconds since the epoch. 0 when the token never expires.",
+			},
+			"issued_at": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Issue time of the token, in seconds since the epoch.",
+			},
+		},
+
+		CustomizeDiff: serviceAccountTokenDiff,
+
+		Description: "Provides an Artifactory service account: a user who can only access Artifactory through the REST API " +
+			"with its token, its group memberships and a rotating scoped token.",
+	}
+}
+
+func unpackServiceAccountUser(d *schema.ResourceData) User {
+	rd := &util.ResourceData{ResourceData: d}
+	return User{
+		Name:                     rd.GetString("name", false),
+		Email:                    rd.GetString("email", false),
+		DisableUIAccess:          true,
+		InternalPasswordDisabled: true,
+		Groups:                   rd.GetSet("groups"),
+	}
+}
+
+func resourceServiceAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	user := unpackServiceAccountUser(d)
+
+	// the password is required by Artifactory, but never used as the internal password is disabled
+	randomPassword, err := password.Generate(32, 4, 4, false, false)
+	if err != nil {
+		return diag.Errorf("failed to generate password. %v", err)
+	}
+	user.Password = randomPassword
+
+	if err := putUser(user, m); err != nil {
+		return deleteServiceAccountUser(user.Name, diag.FromErr(err), m)
+	}
+
+	if _, err := waitForUser(ctx, user.Name, d.Timeout(schema.TimeoutCreate), m); err != nil {
+		return deleteServiceAccountUser(user.Name, diag.FromErr(err), m)
+	}
+
+	// the user is only kept with its token, so a failed apply doesn't leave a service account without token behind
+	if diags := createServiceAccountToken(d, m); diags.HasError() {
+		return deleteServiceAccountUser(user.Name, diags, m)
+	}
+
+	d.SetId(user.Name)
+
+	return resourceServiceAccountRead(ctx, d, m)
+}
+
+func deleteServiceAccountUser(userName string, diags diag.Diagnostics, m interface{}) diag.Diagnostics {
+	resp, err := m.(util.ProvderMetadata).Client.R().Delete(UsersEndpointPath + userName)
+	if err != nil && (resp == nil || resp.StatusCode() != http.StatusNotFound) {
+		return append(diags, diag.Errorf("failed to delete service account %s after a failed creation: %s", userName, err)...)
+	}
+	return diags
+}
+
+// createServiceAccountToken creates a token for the service account. The current token becomes the previous token,
+// and the previous token is revoked once the new token is saved. A failed revocation is a warning, as the token was
+// rotated.
+func createServiceAccountToken(d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	rd := &util.ResourceData{ResourceData: d}
+
+	request := serviceAccountTokenRequest{
+		GrantType:   "client_credentials",
+		Username:    rd.GetString("name", false),
+		Scope:       strings.Join(rd.GetSet("scopes"), " "),
+		ExpiresIn:   rd.GetInt("expires_in", false),
+		Description: rd.GetString("description", false),
+	}
+
+	result := security.AccessTokenPostResponse{}
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetBody(request).
+		SetResult(&result).
+		Post(security.AccessTokensEndpoint)
+	if err != nil {
+		return diag.Errorf("failed to create the token of service account %s: %s", request.Username, err)
+	}
+
+	replacedTokenId := rd.GetString("previous_token_id", false)
+
+	setValue := util.MkLens(d)
+	setValue("previous_token_id", rd.GetString("token_id", false))
+	setValue("previous_access_token", rd.GetString("access_token", false))
+	setValue("token_id", result.TokenId)
+	setValue("access_token", result.AccessToken)
+	setValue("scopes", strings.Split(result.Scope, " "))
+	errors := setValue("expires_in", result.ExpiresIn)
+	if len(errors) > 0 {
+		return diag.Errorf("failed to pack the token of service account %s %q", request.Username, errors)
+	}
+
+	if replacedTokenId != "" {
+		if diags := security.RevokeAccessToken(replacedTokenId, m); diags.HasError() {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to revoke token %s of service account %s", replacedTokenId, request.Username),
+				Detail:   fmt.Sprintf("The token was rotated, but token %s is still valid until it expires or is revoked: %s", replacedTokenId, diags[0].Detail),
+			}}
+		}
+	}
+
+	return nil
+}
+
+func resourceServiceAccountRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	user := User{}
+	resp, err := m.(util.ProvderMetadata).Client.R().SetResult(&user).Get(UsersEndpointPath + d.Id())
+	if err != nil {
+		if resp != nil && resp.StatusCode() == http.StatusNotFound {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	setValue := util.MkLens(d)
+	setValue("name", user.Name)
+	setValue("email", user.Email)
+	errors := setValue("groups", schema.NewSet(schema.HashString, util.CastToInterfaceArr(user.Groups)))
+	if len(errors) > 0 {
+		return diag.Errorf("failed to pack service account %q", errors)
+	}
+
+	return packServiceAccountToken(d, m)
+}
+
+func packServiceAccountToken(d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tokenId := d.Get("token_id").(string)
+	if tokenId == "" {
+		return nil
+	}
+
+	token := serviceAccountToken{}
+	resp, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("id", tokenId).
+		SetResult(&token).
+		Get(security.AccessTokensEndpoint + "/{id}")
+
+	setValue := util.MkLens(d)
+	var errors []error
+	if err != nil {
+		if resp == nil || resp.StatusCode() != http.StatusNotFound {
+			return diag.FromErr(err)
+		}
+		// a token revoked outside of Terraform is created again by the next apply. A token which was never found was
+		// not persisted by Access, and an expired token is rotated when rotate_before_expiry is set.
+		expiry := d.Get("expiry").(int)
+		if d.Get("issued_at").(int) > 0 && (expiry == 0 || int64(expiry) > time.Now().Unix()) {
+			errors = setValue("token_id", "")
+		}
+	} else {
+		setValue("expiry", token.Expiry)
+		errors = setValue("issued_at", token.IssuedAt)
+	}
+
+	if len(errors) > 0 {
+		return diag.Errorf("failed to pack the token of service account %q", errors)
+	}
+
+	return nil
+}
+
+func resourceServiceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChanges("email", "groups") {
+		user := unpackServiceAccountUser(d)
+		if user.Groups == nil {
+			user.Groups = []string{}
+		}
+		_, err := m.(util.ProvderMetadata).Client.R().SetBody(user).Post(UsersEndpointPath + user.Name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var diags diag.Diagnostics
+	if isServiceAccountTokenStale(d.Id(), d.Get("token_id").(string), d.HasChanges("scopes", "expires_in", "description"), d) {
+		diags = createServiceAccountToken(d, m)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, resourceServiceAccountRead(ctx, d, m)...)
+}
+
+// resourceServiceAccountDelete revokes the tokens before the user is deleted. The user is kept when a token can't be
+// revoked, so the next destroy revokes it.
+func resourceServiceAccountDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	for _, key := range []string{"previous_token_id", "token_id"} {
+		if tokenId := d.Get(key).(string); tokenId != "" {
+			if diags := security.RevokeAccessToken(tokenId, m); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
+	_, err := m.(util.ProvderMetadata).Client.R().Delete(UsersEndpointPath + d.Id())
+	if err != nil {
+		return diag.Errorf("service account %s not deleted. %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+type resourceGetter interface {
+	Get(string) interface{}
+}
+
+// isServiceAccountTokenStale reports whether the token has to be created again: when its settings changed, it was
+// revoked, or its rotation is due
+func isServiceAccountTokenStale(id, tokenId string, settingsChanged bool, d resourceGetter) bool {
+	if id == "" {
+		return false
+	}
+
+	return settingsChanged || tokenId == "" || security.IsTokenRotationDue(
+		d.Get("issued_at").(int),
+		d.Get("expiry").(int),
+		d.Get("rotate_before_expiry").(int),
+		d.Get("rotation_period").(int),
+		time.Now(),
+	)
+}
+
+func serviceAccountTokenDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	rotateBeforeExpiry := diff.Get("rotate_before_expiry").(int)
+	expiresIn := diff.Get("expires_in").(int)
+	if rotateBeforeExpiry > 0 && expiresIn > 0 && rotateBeforeExpiry >= expiresIn {
+		return fmt.Errorf("rotate_before_expiry (%d) must be less than expires_in (%d)", rotateBeforeExpiry, expiresIn)
+	}
+
+	tokenId, _ := diff.GetChange("token_id")
+	settingsChanged := diff.HasChange("scopes") || diff.HasChange("expires_in") || diff.HasChange("description")
+	if !isServiceAccountTokenStale(diff.Id(), tokenId.(string), settingsChanged, diff) {
+		return nil
+	}
+
+	for _, key := range serviceAccountTokenKeys {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
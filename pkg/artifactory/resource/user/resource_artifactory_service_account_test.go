@@ -0,0 +1,179 @@
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/security"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/user"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func TestAccServiceAccount(t *testing.T) {
+	const serviceAccount = `
+		resource "artifactory_service_account" "{{ .name }}" {
+			name        = "{{ .username }}"
+			email       = "{{ .username }}@ci.example.com"
+			groups      = ["readers"]
+			expires_in  = 0
+			description = "{{ .description }}"
+		}
+	`
+	id, fqrn, name := test.MkNames("ci-", "artifactory_service_account")
+	username := fmt.Sprintf("ci-bot-%d", id)
+	params := map[string]string{
+		"name":        name,
+		"username":    username,
+		"description": "CI token",
+	}
+	config := util.ExecuteTemplate(name, serviceAccount, params)
+	params["description"] = "CI token, rotated"
+	rotatedConfig := util.ExecuteTemplate(name, serviceAccount, params)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckServiceAccountDestroy(fqrn),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "name", username),
+					resource.TestCheckResourceAttr(fqrn, "groups.#", "1"),
+					resource.TestCheckResourceAttr(fqrn, "groups.0", "readers"),
+					resource.TestCheckResourceAttrSet(fqrn, "access_token"),
+					resource.TestCheckResourceAttrSet(fqrn, "token_id"),
+					resource.TestCheckResourceAttr(fqrn, "previous_token_id", ""),
+					testAccCheckServiceAccountUser(username),
+				),
+			},
+			{
+				Config: rotatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(fqrn, "access_token"),
+					resource.TestCheckResourceAttrSet(fqrn, "previous_token_id"),
+					resource.TestCheckResourceAttrSet(fqrn, "previous_access_token"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckServiceAccountUser checks that the service account can't use the UI or an internal password
+func testAccCheckServiceAccountUser(userName string) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		userObj := user.User{}
+		_, err := acctest.Provider.Meta().(util.ProvderMetadata).Client.R().
+			SetResult(&userObj).
+			Get(user.UsersEndpointPath + userName)
+		if err != nil {
+			return err
+		}
+		if !userObj.DisableUIAccess || !userObj.InternalPasswordDisabled {
+			return fmt.Errorf("expected service account %s to have UI access and internal password disabled, got %t and %t",
+				userName, userObj.DisableUIAccess, userObj.InternalPasswordDisabled)
+		}
+		return nil
+	}
+}
+
+func testAccCheckServiceAccountDestroy(id string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		client := acctest.Provider.Meta().(util.ProvderMetadata).Client
+
+		rs, ok := s.RootModule().Resources[id]
+		if !ok {
+			return fmt.Errorf("err: Resource id[%s] not found", id)
+		}
+
+		for _, key := range []string{"token_id", "previous_token_id"} {
+			resp, err := client.R().Get(security.AccessTokensEndpoint + "/" + rs.Primary.Attributes[key])
+			if err == nil || resp == nil || resp.StatusCode() != http.StatusNotFound {
+				return fmt.Errorf("error: token %s of service account %s not revoked", rs.Primary.Attributes[key], rs.Primary.ID)
+			}
+		}
+
+		resp, err := client.R().Head(user.UsersEndpointPath + rs.Primary.ID)
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				return nil
+			}
+			return err
+		}
+
+		return fmt.Errorf("error: service account %s still exists", rs.Primary.ID)
+	}
+}
+
+func TestServiceAccountRotationWithFailedRevocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/"+security.AccessTokensEndpoint:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"token_id": "new-id", "access_token": "new-token", "scope": "applied-permissions/user"})
+		case r.Method == http.MethodGet && r.URL.Path == "/"+security.AccessTokensEndpoint+"/new-id":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"token_id": "new-id"})
+		case r.Method == http.MethodPost && r.URL.Path == "/"+user.UsersEndpointPath+"ci":
+		case r.Method == http.MethodGet && r.URL.Path == "/"+user.UsersEndpointPath+"ci":
+			_ = json.NewEncoder(w).Encode(user.User{Name: "ci", Email: "ci@ci.example.com"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/"+security.AccessTokensEndpoint+"/replaced-id":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serviceAccount := user.ResourceArtifactoryServiceAccount()
+	d := schema.TestResourceDataRaw(t, serviceAccount.Schema, map[string]interface{}{
+		"name":  "ci",
+		"email": "ci@ci.example.com",
+	})
+	d.SetId("ci")
+	for key, value := range map[string]interface{}{"access_token": "current-token", "token_id": "current-id", "previous_token_id": "replaced-id", "issued_at": 1} {
+		if err := d.Set(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := d.Set("rotation_period", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	diags := serviceAccount.UpdateContext(context.Background(), d, util.ProvderMetadata{Client: restyClient})
+	if diags.HasError() {
+		t.Fatalf("expected the failed revocation to be a warning, got: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning for the failed revocation, got: %v", diags)
+	}
+
+	expected := map[string]string{
+		"token_id":              "new-id",
+		"access_token":          "new-token",
+		"previous_token_id":     "current-id",
+		"previous_access_token": "current-token",
+	}
+	for key, value := range expected {
+		if actual := d.Get(key); actual != value {
+			t.Errorf("expected %s to be %q, got %q", key, value, actual)
+		}
+	}
+}
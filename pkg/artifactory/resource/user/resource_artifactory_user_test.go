@@ -1,13 +1,21 @@
 package user_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/user"
+	"github.com/jfrog/terraform-provider-shared/client"
 	"github.com/jfrog/terraform-provider-shared/test"
 	"github.com/jfrog/terraform-provider-shared/util"
 	"github.com/jfrog/terraform-provider-shared/validator"
@@ -299,3 +307,88 @@ func testAccCheckUserDestroy(id string) func(*terraform.State) error {
 		return fmt.Errorf("error: User %s still exists", rs.Primary.ID)
 	}
 }
+
+// TestUserReadLifecycle reads a user locked and disabled outside of Terraform, with and without the Access users API
+func TestUserReadLifecycle(t *testing.T) {
+	var lifecycleRequests []string
+	accessUsersAPI := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.URL.Path == "/"+user.UsersEndpointPath+"jdoe":
+			_ = json.NewEncoder(w).Encode(user.User{Name: "jdoe", Email: "jdoe@tempurl.org"})
+		case r.URL.Path == "/"+user.LockedUsersEndpoint:
+			lifecycleRequests = append(lifecycleRequests, r.URL.Path)
+			_, _ = w.Write([]byte(`["jdoe"]`))
+		case strings.HasPrefix(r.URL.Path, "/access/api/v2/users/"):
+			lifecycleRequests = append(lifecycleRequests, r.URL.Path)
+			if !accessUsersAPI {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(user.AccessUserStatus{Status: user.UserStatusDisabled})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := util.ProvderMetadata{Client: restyClient}
+
+	testCases := []struct {
+		name             string
+		accessUsersAPI   bool
+		expectedWarnings int
+		expectedDisabled bool
+	}{
+		{
+			name:             "with Access users API",
+			accessUsersAPI:   true,
+			expectedDisabled: true,
+		},
+		{
+			name:             "without Access users API",
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lifecycleRequests = nil
+			accessUsersAPI = tc.accessUsersAPI
+			userResource := user.ResourceArtifactoryUser()
+			d := schema.TestResourceDataRaw(t, userResource.Schema, map[string]interface{}{"name": "jdoe", "email": "jdoe@tempurl.org"})
+			d.SetId("jdoe")
+
+			diags := userResource.ReadContext(context.Background(), d, m)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			warnings := 0
+			for _, diagnostic := range diags {
+				if diagnostic.Severity == diag.Warning {
+					warnings++
+				}
+			}
+			if warnings != tc.expectedWarnings {
+				t.Errorf("expected %d warnings, got: %v", tc.expectedWarnings, diags)
+			}
+			if len(lifecycleRequests) != 2 {
+				t.Errorf("expected the lock and the status to be read, got %v", lifecycleRequests)
+			}
+			if !d.Get("locked").(bool) {
+				t.Error("expected the lock of the user to be refreshed")
+			}
+			if disabled := d.Get("disabled").(bool); disabled != tc.expectedDisabled {
+				t.Errorf("expected disabled to be %t, got %t", tc.expectedDisabled, disabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sethvargo/go-password/password"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const UsersDeleteEndpoint = "artifactory/api/security/users/usersDelete"
+
+type UsersDelete struct {
+	Usernames []string `json:"usernames"`
+}
+
+var bulkUserResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotEmpty),
+			Description:      "Username for user.",
+		},
+		"email": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validator.IsEmail,
+			Description:      "Email for user.",
+		},
+		"password": {
+			Type:        schema.TypeString,
+			Sensitive:   true,
+			Optional:    true,
+			Description: "Password for the user. When omitted, a random password is generated, as for `artifactory_user`.",
+		},
+		"admin": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "When enabled, this user is an administrator with all the ensuing privileges.",
+		},
+		"profile_updatable": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "When enabled, this user can update their profile details, except for the password.",
+		},
+		"disable_ui_access": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "When enabled, this user can only access the system through the REST API.",
+		},
+		"internal_password_disabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "When enabled, disables the fallback mechanism for using an internal password when external authentication (such as LDAP) is enabled.",
+		},
+		"groups": {
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Set:         schema.HashString,
+			Optional:    true,
+			Description: "List of groups this user is a part of. When not set, the user is not a member of any group.",
+		},
+	},
+}
+
+// hashBulkUser identifies the users by name, so a change of a user is planned as an update of the user instead of
+// the replacement of the user
+func hashBulkUser(v interface{}) int {
+	return schema.HashString(v.(map[string]interface{})["name"])
+}
+
+func ResourceArtifactoryUsersBulk() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUsersBulkCreate,
+		ReadContext:   resourceUsersBulkRead,
+		UpdateContext: resourceUsersBulkUpdate,
+		DeleteContext: resourceUsersBulkDelete,
+
+		Schema: map[string]*schema.Schema{
+			"users": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Set:         hashBulkUser,
+				Elem:        bulkUserResource,
+				Description: "The users, identified by name. A user failing to be created, updated or deleted is reported as an error, without aborting the other users, and is applied again by the next apply.",
+			},
+		},
+
+		CustomizeDiff: verifyBulkUserNames,
+
+		Description: "Provides a resource to manage a batch of Artifactory users, e.g. decoded from a JSON or CSV file. " +
+			"The users are diffed as a set, and deleted with the bulk delete API. Artifactory has no bulk create API, so the " +
+			"added and changed users aren't created with a bulk request: each user is checked and created, or updated, with " +
+			"its own requests.",
+	}
+}
+
+func unpackBulkUser(v interface{}) User {
+	u := v.(map[string]interface{})
+	user := User{
+		Name:                     u["name"].(string),
+		Email:                    u["email"].(string),
+		Password:                 u["password"].(string),
+		Admin:                    u["admin"].(bool),
+		ProfileUpdatable:         u["profile_updatable"].(bool),
+		DisableUIAccess:          u["disable_ui_access"].(bool),
+		InternalPasswordDisabled: u["internal_password_disabled"].(bool),
+		Groups:                   []string{},
+	}
+	for _, group := range u["groups"].(*schema.Set).List() {
+		user.Groups = append(user.Groups, group.(string))
+	}
+	return user
+}
+
+// packBulkUser keeps the password of the state, as it is never returned by Artifactory
+func packBulkUser(user User, statePassword string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                       user.Name,
+		"email":                      user.Email,
+		"password":                   statePassword,
+		"admin":                      user.Admin,
+		"profile_updatable":          user.ProfileUpdatable,
+		"disable_ui_access":          user.DisableUIAccess,
+		"internal_password_disabled": user.InternalPasswordDisabled,
+		"groups":                     schema.NewSet(schema.HashString, util.CastToInterfaceArr(user.Groups)),
+	}
+}
+
+func bulkUsersByName(set *schema.Set) map[string]map[string]interface{} {
+	users := map[string]map[string]interface{}{}
+	for _, v := range set.List() {
+		u := v.(map[string]interface{})
+		users[u["name"].(string)] = u
+	}
+	return users
+}
+
+func verifyBulkUserNames(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	names := map[string]bool{}
+	for _, v := range diff.Get("users").(*schema.Set).List() {
+		name := v.(map[string]interface{})["name"].(string)
+		// the names of the users may not be known yet, e.g. when computed from another resource
+		if name == "" {
+			continue
+		}
+		if names[name] {
+			return fmt.Errorf("user %s is defined more than once", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+func bulkUserError(summary string, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   err.Error(),
+	}
+}
+
+// createBulkUser creates a user, with the workaround of putUser for the users without groups. A user which already
+// exists isn't replaced, as it isn't managed by the resource.
+func createBulkUser(user User, m interface{}) error {
+	resp, err := m.(util.ProvderMetadata).Client.R().Head(UsersEndpointPath + user.Name)
+	if err == nil {
+		return fmt.Errorf("user %s already exists. Remove it from `users`, or delete it from Artifactory so it is created by this resource", user.Name)
+	}
+	if resp == nil || resp.StatusCode() != http.StatusNotFound {
+		return fmt.Errorf("failed to check whether user %s exists: %s", user.Name, err)
+	}
+
+	if user.Password == "" {
+		randomPassword, err := password.Generate(12, 1, 1, false, false)
+		if err != nil {
+			return fmt.Errorf("failed to generate password. %v", err)
+		}
+		user.Password = randomPassword
+	}
+
+	_, err = m.(util.ProvderMetadata).Client.R().SetBody(user).Put(UsersEndpointPath + user.Name)
+	if err != nil {
+		return err
+	}
+
+	if len(user.Groups) == 0 {
+		_, err = m.(util.ProvderMetadata).Client.R().SetBody(user).Post(UsersEndpointPath + user.Name)
+	}
+	return err
+}
+
+func DeleteUsers(names []string, m interface{}) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetBody(UsersDelete{Usernames: names}).
+		Post(UsersDeleteEndpoint)
+	return err
+}
+
+func resourceUsersBulkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var created []interface{}
+
+	for _, v := range d.Get("users").(*schema.Set).List() {
+		user := unpackBulkUser(v)
+		if err := createBulkUser(user, m); err != nil {
+			diags = append(diags, bulkUserError(fmt.Sprintf("Failed to create user %s", user.Name), err))
+			continue
+		}
+		created = append(created, v)
+	}
+
+	// the users which failed are left out of the state, so the next apply creates them. Terraform taints the resource
+	// when a user fails, as for any create returning an error
+	d.SetId(resource.UniqueId())
+	if err := d.Set("users", schema.NewSet(hashBulkUser, created)); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return append(diags, resourceUsersBulkRead(ctx, d, m)...)
+}
+
+func resourceUsersBulkRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var users []interface{}
+
+	for _, v := range d.Get("users").(*schema.Set).List() {
+		stateUser := v.(map[string]interface{})
+		user := User{}
+		resp, err := m.(util.ProvderMetadata).Client.R().SetResult(&user).Get(UsersEndpointPath + stateUser["name"].(string))
+		if err != nil {
+			if resp != nil && resp.StatusCode() == http.StatusNotFound {
+				continue
+			}
+			return diag.FromErr(err)
+		}
+		users = append(users, packBulkUser(user, stateUser["password"].(string)))
+	}
+
+	if err := d.Set("users", schema.NewSet(hashBulkUser, users)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceUsersBulkUpdate deletes the removed users with a single request, and creates or updates the other users
+// one by one, as Artifactory has no bulk create API. The state keeps the previous value of the users which failed, so the next apply applies them again.
+func resourceUsersBulkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	o, n := d.GetChange("users")
+	oldUsers := bulkUsersByName(o.(*schema.Set))
+	newUsers := bulkUsersByName(n.(*schema.Set))
+	state := map[string]map[string]interface{}{}
+	for name, u := range oldUsers {
+		state[name] = u
+	}
+
+	var removed []string
+	for name := range oldUsers {
+		if _, ok := newUsers[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	if err := DeleteUsers(removed, m); err != nil {
+		diags = append(diags, bulkUserError(fmt.Sprintf("Failed to delete users %v", removed), err))
+	} else {
+		for _, name := range removed {
+			delete(state, name)
+		}
+	}
+
+	for name, u := range newUsers {
+		old, exists := oldUsers[name]
+		if exists && schema.HashResource(bulkUserResource)(old) == schema.HashResource(bulkUserResource)(u) {
+			continue
+		}
+
+		user := unpackBulkUser(u)
+		var err error
+		if exists {
+			_, err = m.(util.ProvderMetadata).Client.R().SetBody(user).Post(UsersEndpointPath + user.Name)
+		} else {
+			err = createBulkUser(user, m)
+		}
+		if err != nil {
+			diags = append(diags, bulkUserError(fmt.Sprintf("Failed to apply user %s", name), err))
+			continue
+		}
+		state[name] = u
+	}
+
+	var users []interface{}
+	for _, u := range state {
+		users = append(users, u)
+	}
+	if err := d.Set("users", schema.NewSet(hashBulkUser, users)); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return append(diags, resourceUsersBulkRead(ctx, d, m)...)
+}
+
+func resourceUsersBulkDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var names []string
+	for name := range bulkUsersByName(d.Get("users").(*schema.Set)) {
+		names = append(names, name)
+	}
+
+	if err := DeleteUsers(names, m); err != nil {
+		return diag.Errorf("users %v not deleted. %s", names, err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,204 @@
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/user"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// usersStandIn answers the users API the way Artifactory does, and refuses to create the users named "invalid-*"
+func usersStandIn(t *testing.T) (util.ProvderMetadata, map[string]user.User) {
+	var lock sync.Mutex
+	users := map[string]user.User{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if r.URL.Path == "/"+user.UsersDeleteEndpoint {
+			usersDelete := user.UsersDelete{}
+			if err := json.NewDecoder(r.Body).Decode(&usersDelete); err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range usersDelete.Usernames {
+				delete(users, name)
+			}
+			return
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, "/"+user.UsersEndpointPath)
+		switch r.Method {
+		case http.MethodPut, http.MethodPost:
+			if strings.HasPrefix(name, "invalid-") {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":[{"status":400,"message":"Password does not meet the password policy"}]}`))
+				return
+			}
+			u := user.User{}
+			if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+				t.Fatal(err)
+			}
+			users[name] = u
+		case http.MethodGet, http.MethodHead:
+			u, ok := users[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(u)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return util.ProvderMetadata{Client: restyClient}, users
+}
+
+func TestUsersBulk_partialFailure(t *testing.T) {
+	m, users := usersStandIn(t)
+	bulk := user.ResourceArtifactoryUsersBulk()
+
+	d := schema.TestResourceDataRaw(t, bulk.Schema, map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "contractor-1", "email": "contractor-1@example.com", "groups": []interface{}{"readers"}},
+			map[string]interface{}{"name": "invalid-2", "email": "invalid-2@example.com"},
+			map[string]interface{}{"name": "contractor-3", "email": "contractor-3@example.com"},
+		},
+	})
+
+	diags := bulk.CreateContext(context.Background(), d, m)
+	if len(diags) != 1 || diags[0].Severity != diag.Error || !strings.Contains(diags[0].Summary, "invalid-2") {
+		t.Errorf("expected an error for user invalid-2, got: %v", diags)
+	}
+
+	if len(users) != 2 {
+		t.Errorf("expected 2 users to be created, got: %v", users)
+	}
+	if users["contractor-3"].Groups == nil || len(users["contractor-3"].Groups) != 0 {
+		t.Errorf("expected contractor-3 not to be a member of any group, got: %v", users["contractor-3"].Groups)
+	}
+	if state := d.Get("users").(*schema.Set); state.Len() != 2 {
+		t.Errorf("expected the state to only hold the created users, got: %v", state.List())
+	}
+
+	diags = bulk.DeleteContext(context.Background(), d, m)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(users) != 0 {
+		t.Errorf("expected all the users to be deleted, got: %v", users)
+	}
+}
+
+func TestUsersBulk_existingUser(t *testing.T) {
+	m, users := usersStandIn(t)
+	users["existing-1"] = user.User{Name: "existing-1", Email: "owner@example.com", Groups: []string{"admins"}}
+	bulk := user.ResourceArtifactoryUsersBulk()
+
+	d := schema.TestResourceDataRaw(t, bulk.Schema, map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "existing-1", "email": "existing-1@example.com"},
+			map[string]interface{}{"name": "contractor-2", "email": "contractor-2@example.com"},
+		},
+	})
+
+	diags := bulk.CreateContext(context.Background(), d, m)
+	if len(diags) != 1 || diags[0].Severity != diag.Error || !strings.Contains(diags[0].Detail, "user existing-1 already exists") {
+		t.Errorf("expected an error for user existing-1, got: %v", diags)
+	}
+
+	if users["existing-1"].Email != "owner@example.com" {
+		t.Errorf("expected the existing user not to be replaced, got: %v", users["existing-1"])
+	}
+	if _, ok := users["contractor-2"]; !ok {
+		t.Error("expected contractor-2 to be created")
+	}
+	if state := d.Get("users").(*schema.Set); state.Len() != 1 {
+		t.Errorf("expected the state to only hold the created users, got: %v", state.List())
+	}
+}
+
+func TestAccUsersBulk(t *testing.T) {
+	const usersBulk = `
+		locals {
+			contractors = csvdecode(<<-CSV
+				name,email
+				%[2]s-1,%[2]s-1@example.com
+				%[2]s-2,%[2]s-2@example.com
+				%[3]s
+			CSV
+			)
+		}
+
+		resource "artifactory_users_bulk" "%[1]s" {
+			dynamic "users" {
+				for_each = local.contractors
+				content {
+					name   = users.value.name
+					email  = users.value.email
+					groups = ["readers"]
+				}
+			}
+		}
+	`
+	id, fqrn, name := test.MkNames("contractors-", "artifactory_users_bulk")
+	prefix := fmt.Sprintf("contractor%d", id)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      testAccCheckUsersBulkDestroy(prefix+"-1", prefix+"-2", prefix+"-3"),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(usersBulk, name, prefix, fmt.Sprintf("%[1]s-3,%[1]s-3@example.com", prefix)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "users.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs(fqrn, "users.*", map[string]string{
+						"name":     prefix + "-3",
+						"groups.#": "1",
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(usersBulk, name, prefix, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "users.#", "2"),
+					testAccCheckUsersBulkDestroy(prefix+"-3"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckUsersBulkDestroy(names ...string) func(*terraform.State) error {
+	return func(_ *terraform.State) error {
+		client := acctest.Provider.Meta().(util.ProvderMetadata).Client
+		for _, name := range names {
+			resp, err := client.R().Head(user.UsersEndpointPath + name)
+			if err == nil || resp == nil || resp.StatusCode() != http.StatusNotFound {
+				return fmt.Errorf("error: user %s still exists", name)
+			}
+		}
+		return nil
+	}
+}
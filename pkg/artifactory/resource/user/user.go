@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -77,6 +78,41 @@ var baseUserSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "List of groups this user is a part of.",
 	},
+	"disabled": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "(Optional, Default: false) When enabled, the user can't log in or use their tokens, " +
+			"but is kept with their audit trail, permissions and group memberships.",
+	},
+	"password_expired": {
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "(Optional, Default: false) When enabled, the password of the user is expired, and must be changed on the next login. " +
+			"Artifactory doesn't report whether a password is expired, so changes made outside of Terraform are not detected.",
+	},
+	"unlock_on_apply": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "(Optional, Default: false) When enabled, the user is unlocked on apply if they are locked out after too many failed login attempts.",
+	},
+	"locked": {
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the user is locked out after too many failed login attempts.",
+	},
+	"last_logged_in": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time of the last login of the user.",
+	},
+	"realm": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Authentication realm of the user, e.g. `internal`, `ldap` or `saml`.",
+	},
 }
 
 func unpackUser(s *schema.ResourceData) User {
@@ -102,6 +138,8 @@ func PackUser(user User, d *schema.ResourceData) diag.Diagnostics {
 	setValue("admin", user.Admin)
 	setValue("profile_updatable", user.ProfileUpdatable)
 	setValue("disable_ui_access", user.DisableUIAccess)
+	setValue("last_logged_in", user.LastLoggedIn)
+	setValue("realm", user.Realm)
 	errors := setValue("internal_password_disabled", user.InternalPasswordDisabled)
 
 	if user.Groups != nil {
@@ -117,7 +155,7 @@ func PackUser(user User, d *schema.ResourceData) diag.Diagnostics {
 
 const UsersEndpointPath = "artifactory/api/security/users/"
 
-func resourceUserRead(_ context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
+func resourceUserRead(ctx context.Context, rd *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d := &util.ResourceData{ResourceData: rd}
 
 	userName := d.Id()
@@ -131,7 +169,11 @@ func resourceUserRead(_ context.Context, rd *schema.ResourceData, m interface{})
 		}
 		return diag.FromErr(err)
 	}
-	return PackUser(user, rd)
+
+	if diags := PackUser(user, rd); diags.HasError() {
+		return diags
+	}
+	return packUserLifecycle(ctx, rd, m)
 }
 
 func resourceBaseUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}, passwordGenerator func(*User) diag.Diagnostics) diag.Diagnostics {
@@ -143,46 +185,65 @@ func resourceBaseUserCreate(ctx context.Context, d *schema.ResourceData, m inter
 		diags = passwordGenerator(&user)
 	}
 
-	_, err := m.(util.ProvderMetadata).Client.R().SetBody(user).Put(UsersEndpointPath + user.Name)
+	if err := putUser(user, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(user.Name)
+
+	result, err := waitForUser(ctx, user.Name, d.Timeout(schema.TimeoutCreate), m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	PackUser(*result, d)
+
+	if err := applyUserLifecycle(d, m); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	return append(diags, packUserLifecycle(ctx, d, m)...)
+}
+
+// putUser creates the user, or replaces an existing user
+func putUser(user User, m interface{}) error {
+	_, err := m.(util.ProvderMetadata).Client.R().SetBody(user).Put(UsersEndpointPath + user.Name)
+	if err != nil {
+		return err
+	}
+
 	// Artifactory PUT call for creating user with groups attribute set to empty/null always sets groups to "readers".
 	// This is a bug on Artifactory. Below workaround will fix the issue and has to be removed after the artifactory bug is resolved.
 	// Workaround: We use following POST call to update the user's groups config to empty group.
 	// This action will match the expectation for this resource when "groups" attribute is empty or not specified in hcl.
 	if user.Groups == nil {
 		user.Groups = []string{}
-		_, errGroupUpdate := m.(util.ProvderMetadata).Client.R().SetBody(user).Post(UsersEndpointPath + user.Name)
-		if errGroupUpdate != nil {
-			return diag.FromErr(errGroupUpdate)
+		_, err := m.(util.ProvderMetadata).Client.R().SetBody(user).Post(UsersEndpointPath + user.Name)
+		if err != nil {
+			return err
 		}
 	}
 
-	d.SetId(user.Name)
+	return nil
+}
 
-	retryError := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
-		result := &User{}
-		resp, e := m.(util.ProvderMetadata).Client.R().SetResult(result).Get(UsersEndpointPath + user.Name)
+// waitForUser waits until the created user is returned by Artifactory
+func waitForUser(ctx context.Context, name string, timeout time.Duration, m interface{}) (*User, error) {
+	result := &User{}
+	retryError := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		resp, err := m.(util.ProvderMetadata).Client.R().SetResult(result).Get(UsersEndpointPath + name)
 
-		if e != nil {
+		if err != nil {
 			if resp != nil && resp.StatusCode() == http.StatusNotFound {
 				return resource.RetryableError(fmt.Errorf("expected user to be created, but currently not found"))
 			}
 			return resource.NonRetryableError(fmt.Errorf("error describing user: %s", err))
 		}
 
-		PackUser(*result, d)
-
 		return nil
 	})
 
-	if retryError != nil {
-		return diag.FromErr(retryError)
-	}
-
-	return diags
+	return result, retryError
 }
 
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -193,6 +254,10 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
+	if err := applyUserLifecycle(d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(user.Name)
 	return resourceUserRead(ctx, d, m)
 }
@@ -0,0 +1,211 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const (
+	LockedUsersEndpoint      = "artifactory/api/security/lockedUsers"
+	UnlockUserEndpoint       = "artifactory/api/security/unlockUsers/{name}"
+	ExpirePasswordEndpoint   = "artifactory/api/security/users/authorization/expirePassword/{name}"
+	UnexpirePasswordEndpoint = "artifactory/api/security/users/authorization/unexpirePassword/{name}"
+	AccessUserEndpoint       = "access/api/v2/users/{name}"
+)
+
+const (
+	UserStatusEnabled  = "enabled"
+	UserStatusDisabled = "disabled"
+)
+
+// ErrLifecycleUnsupported is returned when the Artifactory version doesn't have the API reporting the lock or the status of a user
+var ErrLifecycleUnsupported = errors.New("API not supported by this Artifactory version")
+
+// lifecycleError wraps the error of a lifecycle API, as ErrLifecycleUnsupported when the API is missing
+func lifecycleError(resp *resty.Response, err error) error {
+	if resp != nil {
+		switch resp.StatusCode() {
+		case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return fmt.Errorf("%w: %s", ErrLifecycleUnsupported, err)
+		}
+	}
+	return err
+}
+
+// AccessUserStatus is the status of a user in the Access users API, which is the only API able to disable a user
+type AccessUserStatus struct {
+	Status string `json:"status"`
+}
+
+func IsUserLocked(userName string, m interface{}) (bool, error) {
+	var lockedUsers []string
+	resp, err := m.(util.ProvderMetadata).Client.R().
+		SetResult(&lockedUsers).
+		Get(LockedUsersEndpoint)
+	if err != nil {
+		return false, lifecycleError(resp, err)
+	}
+
+	for _, lockedUser := range lockedUsers {
+		if lockedUser == userName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func IsUserDisabled(userName string, m interface{}) (bool, error) {
+	status := AccessUserStatus{}
+	resp, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("name", userName).
+		SetResult(&status).
+		Get(AccessUserEndpoint)
+	if err != nil {
+		return false, lifecycleError(resp, err)
+	}
+
+	return status.Status == UserStatusDisabled, nil
+}
+
+func setUserDisabled(userName string, disabled bool, m interface{}) error {
+	status := UserStatusEnabled
+	if disabled {
+		status = UserStatusDisabled
+	}
+
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("name", userName).
+		SetBody(AccessUserStatus{Status: status}).
+		Patch(AccessUserEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to set the status of user %s to %s: %s", userName, status, err)
+	}
+	return nil
+}
+
+func setUserPasswordExpired(userName string, expired bool, m interface{}) error {
+	endpoint := UnexpirePasswordEndpoint
+	if expired {
+		endpoint = ExpirePasswordEndpoint
+	}
+
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("name", userName).
+		Post(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to update the password expiry of user %s: %s", userName, err)
+	}
+	return nil
+}
+
+func unlockUser(userName string, m interface{}) error {
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("name", userName).
+		Post(UnlockUserEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to unlock user %s: %s", userName, err)
+	}
+	return nil
+}
+
+// applyUserLifecycle disables, expires the password of, and unlocks the user as configured. On create, only the
+// attributes differing from the state of a new user are applied.
+func applyUserLifecycle(d *schema.ResourceData, m interface{}) error {
+	userName := d.Get("name").(string)
+
+	if d.HasChange("disabled") {
+		if err := setUserDisabled(userName, d.Get("disabled").(bool), m); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("password_expired") {
+		if err := setUserPasswordExpired(userName, d.Get("password_expired").(bool), m); err != nil {
+			return err
+		}
+	}
+
+	if locked, _ := d.GetChange("locked"); d.Get("unlock_on_apply").(bool) && locked.(bool) {
+		if err := unlockUser(userName, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// packUserLifecycle reads the lock and the status of the user, so a user locked or disabled outside of Terraform is
+// detected. An API missing in the Artifactory version is reported as a warning, and the attribute is left unchanged.
+func packUserLifecycle(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	userName := d.Get("name").(string)
+
+	var diags diag.Diagnostics
+	var packErrors []error
+
+	locked, err := IsUserLocked(userName, m)
+	switch {
+	case errors.Is(err, ErrLifecycleUnsupported):
+		diags = append(diags, lifecycleWarning("locked", err))
+	case err != nil:
+		return diag.Errorf("failed to get the locked users: %s", err)
+	default:
+		if err := d.Set("locked", locked); err != nil {
+			packErrors = append(packErrors, err)
+		}
+	}
+
+	disabled, err := IsUserDisabled(userName, m)
+	switch {
+	case errors.Is(err, ErrLifecycleUnsupported):
+		diags = append(diags, lifecycleWarning("disabled", err))
+	case err != nil:
+		return diag.Errorf("failed to get the status of user %s: %s", userName, err)
+	default:
+		if err := d.Set("disabled", disabled); err != nil {
+			packErrors = append(packErrors, err)
+		}
+	}
+
+	if len(packErrors) > 0 {
+		return diag.Errorf("failed to pack user %q", packErrors)
+	}
+
+	return diags
+}
+
+func lifecycleWarning(key string, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s attribute not refreshed", key),
+		Detail:   err.Error(),
+	}
+}
+
+// importUser sets the defaults of the attributes Artifactory doesn't report, so the imported state matches the configuration
+func importUser(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	setValue := util.MkLens(d)
+	setValue("password_expired", false)
+	errors := setValue("unlock_on_apply", false)
+	if errors != nil && len(errors) > 0 {
+		return nil, fmt.Errorf("failed to import user %q", errors)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// unlockUserDiff plans the unlock of a locked user when unlock_on_apply is enabled
+func unlockUserDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.Get("unlock_on_apply").(bool) || !diff.Get("locked").(bool) {
+		return nil
+	}
+
+	return diff.SetNew("locked", false)
+}
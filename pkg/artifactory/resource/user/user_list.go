@@ -0,0 +1,103 @@
+package user
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const (
+	AccessUsersEndpoint = "access/api/v2/users"
+	// UsersListPageSize is the number of users requested per page of the Access users API
+	UsersListPageSize = 100
+)
+
+// UserSummary is a user as returned by the listing of the Access users API
+type UserSummary struct {
+	Name   string `json:"username"`
+	Realm  string `json:"realm"`
+	Status string `json:"status"`
+}
+
+type userList struct {
+	Users  []UserSummary `json:"users"`
+	Cursor string        `json:"cursor"`
+}
+
+// ListUsers returns all the users, following the cursor of the Access users API page after page
+func ListUsers(m interface{}) ([]UserSummary, error) {
+	var users []UserSummary
+	cursor := ""
+	for {
+		page := userList{}
+		req := m.(util.ProvderMetadata).Client.R().
+			SetQueryParam("limit", strconv.Itoa(UsersListPageSize)).
+			SetResult(&page)
+		if cursor != "" {
+			req.SetQueryParam("cursor", cursor)
+		}
+		if _, err := req.Get(AccessUsersEndpoint); err != nil {
+			return nil, err
+		}
+
+		users = append(users, page.Users...)
+		// the last page has no cursor, a cursor which doesn't move would loop forever
+		if page.Cursor == "" || page.Cursor == cursor || len(page.Users) == 0 {
+			return users, nil
+		}
+		cursor = page.Cursor
+	}
+}
+
+// UserFilter selects users on their details. The zero value matches all the users.
+type UserFilter struct {
+	Realm string
+	// Admin, when set, only matches the administrators if true, or the other users if false
+	Admin *bool
+	Group string
+	// InactiveDays, when positive, only matches the users who haven't logged in for at least this number of days,
+	// including those who never logged in
+	InactiveDays int
+}
+
+// MatchesRealm is checked on the listing, before the details of the user are retrieved
+func (f UserFilter) MatchesRealm(realm string) bool {
+	return f.Realm == "" || f.Realm == realm
+}
+
+func (f UserFilter) Matches(user User, now time.Time) (bool, error) {
+	if !f.MatchesRealm(user.Realm) {
+		return false, nil
+	}
+
+	if f.Admin != nil && *f.Admin != user.Admin {
+		return false, nil
+	}
+
+	if f.Group != "" {
+		member := false
+		for _, group := range user.Groups {
+			if group == f.Group {
+				member = true
+				break
+			}
+		}
+		if !member {
+			return false, nil
+		}
+	}
+
+	if f.InactiveDays > 0 && user.LastLoggedIn != "" {
+		lastLoggedIn, err := time.Parse(time.RFC3339, user.LastLoggedIn)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse the last login of user %s: %s", user.Name, err)
+		}
+		if now.Sub(lastLoggedIn) < time.Duration(f.InactiveDays)*24*time.Hour {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
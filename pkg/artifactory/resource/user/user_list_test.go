@@ -0,0 +1,102 @@
+package user_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/user"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// accessUsersStandIn answers the Access users listing the way Artifactory does, one user per page
+func accessUsersStandIn(t *testing.T, names ...string) util.ProvderMetadata {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+user.AccessUsersEndpoint {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		index := 0
+		for i, name := range names {
+			if name == r.URL.Query().Get("cursor") {
+				index = i + 1
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if index >= len(names) {
+			_, _ = w.Write([]byte(`{"users":[]}`))
+			return
+		}
+		cursor := ""
+		if index < len(names)-1 {
+			cursor = names[index]
+		}
+		_, _ = fmt.Fprintf(w, `{"users":[{"username":"%s","realm":"internal","status":"enabled"}],"cursor":"%s"}`, names[index], cursor)
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return util.ProvderMetadata{Client: restyClient}
+}
+
+func TestListUsers(t *testing.T) {
+	m := accessUsersStandIn(t, "admin", "anonymous", "jdoe")
+
+	users, err := user.ListUsers(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 3 || users[0].Name != "admin" || users[2].Name != "jdoe" {
+		t.Errorf("expected the users of all the pages, got: %v", users)
+	}
+}
+
+func TestUserFilter(t *testing.T) {
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	admin := true
+	jdoe := user.User{
+		Name:         "jdoe",
+		Realm:        "ldap",
+		Groups:       []string{"readers", "developers"},
+		LastLoggedIn: "2023-05-01T10:00:00.000Z",
+	}
+
+	testCases := []struct {
+		name    string
+		filter  user.UserFilter
+		user    user.User
+		matches bool
+	}{
+		{"no filter", user.UserFilter{}, jdoe, true},
+		{"realm", user.UserFilter{Realm: "ldap"}, jdoe, true},
+		{"other realm", user.UserFilter{Realm: "internal"}, jdoe, false},
+		{"admin", user.UserFilter{Admin: &admin}, jdoe, false},
+		{"group", user.UserFilter{Group: "developers"}, jdoe, true},
+		{"other group", user.UserFilter{Group: "deployers"}, jdoe, false},
+		{"inactive", user.UserFilter{InactiveDays: 30}, jdoe, true},
+		{"active", user.UserFilter{InactiveDays: 90}, jdoe, false},
+		{"never logged in", user.UserFilter{InactiveDays: 90}, user.User{Name: "new"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, err := tc.filter.Matches(tc.user, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if matches != tc.matches {
+				t.Errorf("expected %v, got %v", tc.matches, matches)
+			}
+		})
+	}
+}
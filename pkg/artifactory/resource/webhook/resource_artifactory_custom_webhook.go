@@ -0,0 +1,415 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"text/template/parse"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/jfrog/terraform-provider-shared/validator"
+)
+
+const CustomHandlerType = "custom-webhook"
+
+type CustomBaseParams struct {
+	Key         string          `json:"key"`
+	Description string          `json:"description"`
+	Enabled     bool            `json:"enabled"`
+	EventFilter EventFilter     `json:"event_filter"`
+	Handlers    []CustomHandler `json:"handlers"`
+}
+
+func (w CustomBaseParams) Id() string {
+	return w.Key
+}
+
+type CustomHandler struct {
+	HandlerType string     `json:"handler_type"`
+	Url         string     `json:"url"`
+	Secrets     []KeyValue `json:"secrets"`
+	Proxy       string     `json:"proxy"`
+	HttpHeaders []KeyValue `json:"http_headers"`
+	Payload     string     `json:"payload"`
+}
+
+type KeyValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+var customHandlerResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"url": {
+			Type:     schema.TypeString,
+			Required: true,
+			ValidateDiagFunc: validation.ToDiagFunc(
+				validation.All(
+					validation.IsURLWithHTTPorHTTPS,
+					validation.StringIsNotEmpty,
+				),
+			),
+			Description: "Specifies the URL that the Webhook invokes. This will be the URL that Artifactory will send an HTTP POST request to.",
+		},
+		"secrets": {
+			Type:      schema.TypeMap,
+			Optional:  true,
+			Sensitive: true,
+			Elem:      &schema.Schema{Type: schema.TypeString},
+			Description: "Sensitive values, e.g. tokens, injected in the headers and the payload with the `{{ .secrets.<name> }}` format. " +
+				"The values are encrypted by Artifactory, and never returned.",
+		},
+		"proxy": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateDiagFunc: validator.All(
+				validator.StringIsNotEmpty,
+				validator.StringIsNotURL,
+			),
+			Description: "Proxy key from Artifactory UI (Administration -> Proxies -> Configuration)",
+		},
+		"http_headers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "HTTP headers you wish to use to invoke the Webhook, comprise of key/value pair. The values can use the secrets.",
+		},
+		"payload": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validatePayload,
+			Description: "Template of the HTTP body of the request, e.g. `{\"text\": \"{{ .data.repo_key }} changed\"}`. " +
+				"The event is available as `{{ .data }}`, and the secrets as `{{ .secrets.<name> }}`.",
+		},
+	},
+}
+
+var customWebhookSchema = func(webhookType string) map[string]*schema.Schema {
+	return util.MergeMaps(domainSchemaLookup(currentSchemaVersion, webhookType)[webhookType], map[string]*schema.Schema{
+		"handler": {
+			Type:     schema.TypeSet,
+			Required: true,
+			MinItems: 1,
+			Elem:     customHandlerResource,
+		},
+	})
+}
+
+func parsePayload(payload string) (*parse.Tree, error) {
+	tree := parse.New("payload")
+	// the functions available to the templates are only known to Artifactory
+	tree.Mode = parse.SkipFuncCheck
+	return tree.Parse(payload, "", "", map[string]*parse.Tree{})
+}
+
+var validatePayload = validation.ToDiagFunc(func(v interface{}, k string) ([]string, []error) {
+	if _, err := parsePayload(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("invalid template for %s: %s", k, err)}
+	}
+	return nil, nil
+})
+
+// templateSecrets collects the names of the secrets used by a template, i.e. the fields `.secrets.<name>`
+func templateSecrets(node parse.Node, names map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			templateSecrets(child, names)
+		}
+	case *parse.ActionNode:
+		templateSecrets(n.Pipe, names)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			templateSecrets(cmd, names)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			templateSecrets(arg, names)
+		}
+	case *parse.ChainNode:
+		templateSecrets(n.Node, names)
+	case *parse.FieldNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "secrets" {
+			names[n.Ident[1]] = true
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 2 && n.Ident[0] == "$" && n.Ident[1] == "secrets" {
+			names[n.Ident[2]] = true
+		}
+	case *parse.IfNode:
+		templateSecrets(n.Pipe, names)
+		templateSecrets(n.List, names)
+		templateSecrets(n.ElseList, names)
+	case *parse.RangeNode:
+		templateSecrets(n.Pipe, names)
+		templateSecrets(n.List, names)
+		templateSecrets(n.ElseList, names)
+	case *parse.WithNode:
+		templateSecrets(n.Pipe, names)
+		templateSecrets(n.List, names)
+		templateSecrets(n.ElseList, names)
+	case *parse.TemplateNode:
+		templateSecrets(n.Pipe, names)
+	}
+}
+
+// VerifyHandlerSecrets returns an error when the payload or the headers of a handler use a secret which isn't defined
+func VerifyHandlerSecrets(handler map[string]interface{}) error {
+	templates := map[string]string{"payload": handler["payload"].(string)}
+	for name, value := range handler["http_headers"].(map[string]interface{}) {
+		templates[fmt.Sprintf("http_headers.%s", name)] = value.(string)
+	}
+
+	secrets := handler["secrets"].(map[string]interface{})
+	for key, text := range templates {
+		tree, err := parsePayload(text)
+		if err != nil {
+			return fmt.Errorf("invalid template for %s: %s", key, err)
+		}
+
+		names := map[string]bool{}
+		templateSecrets(tree.Root, names)
+		for name := range names {
+			if _, ok := secrets[name]; !ok {
+				return fmt.Errorf("secret %s used by %s of handler %s is not defined", name, key, handler["url"])
+			}
+		}
+	}
+
+	return nil
+}
+
+var handlerSecretsDiff = func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	tflog.Debug(ctx, "handlerSecretsDiff")
+
+	// the secrets may not be known yet, e.g. when read from another resource
+	if !diff.NewValueKnown("handler") {
+		return nil
+	}
+
+	for _, handler := range diff.Get("handler").(*schema.Set).List() {
+		if err := VerifyHandlerSecrets(handler.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ResourceArtifactoryCustomWebhook(webhookType string) *schema.Resource {
+
+	var unpackKeyValues = func(values map[string]interface{}) []KeyValue {
+		var keyValues []KeyValue
+
+		for key, value := range values {
+			keyValues = append(keyValues, KeyValue{
+				Name:  key,
+				Value: value.(string),
+			})
+		}
+
+		return keyValues
+	}
+
+	var unpackHandlers = func(d *util.ResourceData) []CustomHandler {
+		var webhookHandlers []CustomHandler
+
+		if v, ok := d.GetOk("handler"); ok {
+			for _, handler := range v.(*schema.Set).List() {
+				h := handler.(map[string]interface{})
+				// see unpackHandlers of ResourceArtifactoryWebhook
+				if h["url"].(string) != "" {
+					webhookHandlers = append(webhookHandlers, CustomHandler{
+						HandlerType: CustomHandlerType,
+						Url:         h["url"].(string),
+						Secrets:     unpackKeyValues(h["secrets"].(map[string]interface{})),
+						Proxy:       h["proxy"].(string),
+						HttpHeaders: unpackKeyValues(h["http_headers"].(map[string]interface{})),
+						Payload:     h["payload"].(string),
+					})
+				}
+			}
+		}
+
+		return webhookHandlers
+	}
+
+	var unpackWebhook = func(data *schema.ResourceData) CustomBaseParams {
+		d := &util.ResourceData{ResourceData: data}
+
+		return CustomBaseParams{
+			Key:         d.GetString("key", false),
+			Description: d.GetString("description", false),
+			Enabled:     d.GetBool("enabled", false),
+			EventFilter: EventFilter{
+				Domain:     webhookType,
+				EventTypes: d.GetSet("event_types"),
+				Criteria:   unpackCriteria(d, webhookType),
+			},
+			Handlers: unpackHandlers(d),
+		}
+	}
+
+	var packKeyValues = func(keyValues []KeyValue) map[string]interface{} {
+		values := make(map[string]interface{})
+		for _, keyValue := range keyValues {
+			values[keyValue.Name] = keyValue.Value
+		}
+
+		return values
+	}
+
+	// packSecrets keeps the values of the state, as Artifactory only returns the names of the secrets
+	var packSecrets = func(d *schema.ResourceData, url string, secrets []KeyValue) map[string]interface{} {
+		stateSecrets := map[string]interface{}{}
+		for _, handler := range d.Get("handler").(*schema.Set).List() {
+			h := handler.(map[string]interface{})
+			if h["url"].(string) == url {
+				stateSecrets = h["secrets"].(map[string]interface{})
+			}
+		}
+
+		values := make(map[string]interface{})
+		for _, secret := range secrets {
+			value, ok := stateSecrets[secret.Name]
+			if !ok {
+				value = ""
+			}
+			values[secret.Name] = value
+		}
+
+		return values
+	}
+
+	var packHandlers = func(d *schema.ResourceData, handlers []CustomHandler) []error {
+		setValue := util.MkLens(d)
+
+		var packedHandlers []interface{}
+		for _, handler := range handlers {
+			packedHandlers = append(packedHandlers, map[string]interface{}{
+				"url":          handler.Url,
+				"secrets":      packSecrets(d, handler.Url, handler.Secrets),
+				"proxy":        handler.Proxy,
+				"http_headers": packKeyValues(handler.HttpHeaders),
+				"payload":      handler.Payload,
+			})
+		}
+
+		return setValue("handler", schema.NewSet(schema.HashResource(customHandlerResource), packedHandlers))
+	}
+
+	var packWebhook = func(d *schema.ResourceData, webhook CustomBaseParams) diag.Diagnostics {
+		setValue := util.MkLens(d)
+
+		var errors []error
+
+		errors = append(errors, setValue("key", webhook.Key)...)
+		errors = append(errors, setValue("description", webhook.Description)...)
+		errors = append(errors, setValue("enabled", webhook.Enabled)...)
+		errors = append(errors, setValue("event_types", webhook.EventFilter.EventTypes)...)
+		criteria, _ := webhook.EventFilter.Criteria.(map[string]interface{})
+		errors = append(errors, packCriteria(d, webhookType, criteria)...)
+		errors = append(errors, packHandlers(d, webhook.Handlers)...)
+
+		if len(errors) > 0 {
+			return diag.Errorf("failed to pack custom webhook %q", errors)
+		}
+
+		return nil
+	}
+
+	var readWebhook = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		tflog.Debug(ctx, "readCustomWebhook")
+
+		webhook := CustomBaseParams{}
+
+		webhook.EventFilter.Criteria = domainCriteriaLookup[webhookType]
+
+		_, err := m.(util.ProvderMetadata).Client.R().
+			SetPathParam("webhookKey", data.Id()).
+			SetResult(&webhook).
+			Get(WhUrl)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return packWebhook(data, webhook)
+	}
+
+	var createWebhook = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		tflog.Debug(ctx, "createCustomWebhook")
+
+		webhook := unpackWebhook(data)
+
+		_, err := m.(util.ProvderMetadata).Client.R().
+			SetBody(webhook).
+			AddRetryCondition(retryOnProxyError).
+			Post(webhooksUrl)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		data.SetId(webhook.Id())
+
+		if diags := readWebhook(ctx, data, m); diags.HasError() {
+			return diags
+		}
+
+		return testOnApply(ctx, data, m)
+	}
+
+	var updateWebhook = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+		tflog.Debug(ctx, "updateCustomWebhook")
+
+		webhook := unpackWebhook(data)
+
+		_, err := m.(util.ProvderMetadata).Client.R().
+			SetPathParam("webhookKey", data.Id()).
+			SetBody(webhook).
+			AddRetryCondition(retryOnProxyError).
+			Put(WhUrl)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		data.SetId(webhook.Id())
+
+		if diags := readWebhook(ctx, data, m); diags.HasError() {
+			return diags
+		}
+
+		return testOnApply(ctx, data, m)
+	}
+
+	return &schema.Resource{
+		CreateContext: createWebhook,
+		ReadContext:   readWebhook,
+		UpdateContext: updateWebhook,
+		DeleteContext: deleteWebhook,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: customWebhookSchema(webhookType),
+
+		CustomizeDiff: customdiff.All(
+			eventTypesDiff(webhookType),
+			criteriaDiff(webhookType),
+			handlerSecretsDiff,
+		),
+		Description: "Provides an Artifactory custom webhook resource, which sends a payload built from a template, e.g. to post a message on Slack.",
+	}
+}
@@ -0,0 +1,163 @@
+package webhook_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/webhook"
+	"github.com/jfrog/terraform-provider-shared/test"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func TestVerifyHandlerSecrets(t *testing.T) {
+	testCases := []struct {
+		name     string
+		payload  string
+		headers  map[string]interface{}
+		expected string
+	}{
+		{
+			name:    "defined secrets",
+			payload: `{"text": "{{ .data.repo_key }}", "token": "{{ .secrets.token }}"}`,
+			headers: map[string]interface{}{"Authorization": "Bearer {{ $.secrets.bearer }}"},
+		},
+		{
+			name:     "undefined secret in payload",
+			payload:  `{{ if .data.repo_key }}{{ .secrets.password }}{{ end }}`,
+			headers:  map[string]interface{}{},
+			expected: "secret password used by payload of handler https://tempurl.org is not defined",
+		},
+		{
+			name:     "undefined secret in header",
+			payload:  "",
+			headers:  map[string]interface{}{"X-Token": "{{ .secrets.key }}"},
+			expected: "secret key used by http_headers.X-Token of handler https://tempurl.org is not defined",
+		},
+		{
+			name:     "invalid template",
+			payload:  `{"text": "{{ .data.repo_key "}`,
+			headers:  map[string]interface{}{},
+			expected: "invalid template for payload",
+		},
+		{
+			name:    "function unknown to the provider",
+			payload: `{{ .data.repo_key | upper }}`,
+			headers: map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := webhook.VerifyHandlerSecrets(map[string]interface{}{
+				"url":          "https://tempurl.org",
+				"payload":      tc.payload,
+				"http_headers": tc.headers,
+				"secrets":      map[string]interface{}{"token": "fake-token", "bearer": "fake-bearer"},
+			})
+
+			if tc.expected == "" {
+				if err != nil {
+					t.Errorf("expected no error, got: %s", err)
+				}
+				return
+			}
+			if err == nil || !regexp.MustCompile(regexp.QuoteMeta(tc.expected)).MatchString(err.Error()) {
+				t.Errorf("expected error %q, got: %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestAccCustomWebhook(t *testing.T) {
+	_, fqrn, name := test.MkNames("custom-webhook-", "artifactory_artifact_custom_webhook")
+
+	params := map[string]interface{}{
+		"webhookName": name,
+	}
+	webhookConfig := util.ExecuteTemplate("TestAccCustomWebhook", `
+		resource "artifactory_artifact_custom_webhook" "{{ .webhookName }}" {
+			key         = "{{ .webhookName }}"
+			description = "test description"
+			event_types = ["deployed", "deleted"]
+			criteria {
+				any_local  = true
+				any_remote = false
+				repo_keys  = []
+			}
+			handler {
+				url     = "https://tempurl.org"
+				secrets = {
+					token = "fake-token"
+				}
+				http_headers = {
+					Authorization = "Bearer {{"{{"}} .secrets.token {{"}}"}}"
+				}
+				payload = "{ \"text\": \"{{"{{"}} .data.repo_key {{"}}"}} {{"{{"}} .event_type {{"}}"}}\" }"
+			}
+		}
+	`, params)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.VerifyDeleted(fqrn, testCheckWebhook),
+
+		Steps: []resource.TestStep{
+			{
+				Config: webhookConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "key", name),
+					resource.TestCheckResourceAttr(fqrn, "handler.#", "1"),
+					resource.TestCheckResourceAttr(fqrn, "handler.0.url", "https://tempurl.org"),
+					resource.TestCheckResourceAttr(fqrn, "handler.0.secrets.token", "fake-token"),
+					resource.TestCheckResourceAttr(fqrn, "handler.0.http_headers.Authorization", "Bearer {{ .secrets.token }}"),
+					resource.TestCheckResourceAttr(fqrn, "handler.0.payload", `{ "text": "{{ .data.repo_key }} {{ .event_type }}" }`),
+				),
+			},
+			{
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"handler"},
+			},
+		},
+	})
+}
+
+func TestAccCustomWebhookPayloadValidation(t *testing.T) {
+	for _, payload := range []string{`{{ .data.repo_key`, `{{ .secrets.undefined }}`} {
+		_, fqrn, name := test.MkNames("custom-webhook-", "artifactory_artifact_custom_webhook")
+
+		webhookConfig := fmt.Sprintf(`
+			resource "artifactory_artifact_custom_webhook" "%s" {
+				key         = "%s"
+				event_types = ["deployed"]
+				criteria {
+					any_local  = true
+					any_remote = false
+					repo_keys  = []
+				}
+				handler {
+					url     = "https://tempurl.org"
+					payload = %q
+				}
+			}
+		`, name, name, payload)
+
+		resource.Test(t, resource.TestCase{
+			PreCheck:          func() { acctest.PreCheck(t) },
+			ProviderFactories: acctest.ProviderFactories,
+			CheckDestroy:      acctest.VerifyDeleted(fqrn, testCheckWebhook),
+
+			Steps: []resource.TestStep{
+				{
+					Config:      webhookConfig,
+					ExpectError: regexp.MustCompile(`invalid template|secret undefined used by payload`),
+				},
+			},
+		})
+	}
+}
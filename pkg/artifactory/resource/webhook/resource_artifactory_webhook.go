@@ -24,16 +24,24 @@ var TypesSupported = []string{
 	"release_bundle",
 	"distribution",
 	"artifactory_release_bundle",
+	"user",
+	"release_bundle_v2",
+	"release_bundle_v2_promotion",
+	"artifact_lifecycle",
 }
 
 var DomainEventTypesSupported = map[string][]string{
-	"artifact":                   {"deployed", "deleted", "moved", "copied", "cached"},
-	"artifact_property":          {"added", "deleted"},
-	"docker":                     {"pushed", "deleted", "promoted"},
-	"build":                      {"uploaded", "deleted", "promoted"},
-	"release_bundle":             {"created", "signed", "deleted"},
-	"distribution":               {"distribute_started", "distribute_completed", "distribute_aborted", "distribute_failed", "delete_started", "delete_completed", "delete_failed"},
-	"artifactory_release_bundle": {"received", "delete_started", "delete_completed", "delete_failed"},
+	"artifact":                    {"deployed", "deleted", "moved", "copied", "cached"},
+	"artifact_property":           {"added", "deleted"},
+	"docker":                      {"pushed", "deleted", "promoted"},
+	"build":                       {"uploaded", "deleted", "promoted"},
+	"release_bundle":              {"created", "signed", "deleted"},
+	"distribution":                {"distribute_started", "distribute_completed", "distribute_aborted", "distribute_failed", "delete_started", "delete_completed", "delete_failed"},
+	"artifactory_release_bundle":  {"received", "delete_started", "delete_completed", "delete_failed"},
+	"user":                        {"created", "deleted", "locked", "login_failure"},
+	"release_bundle_v2":           {"release_bundle_v2_started", "release_bundle_v2_completed", "release_bundle_v2_failed"},
+	"release_bundle_v2_promotion": {"release_bundle_v2_promotion_started", "release_bundle_v2_promotion_completed", "release_bundle_v2_promotion_failed"},
+	"artifact_lifecycle":          {"archive", "restore"},
 }
 
 type BaseParams struct {
@@ -51,7 +59,7 @@ func (w BaseParams) Id() string {
 type EventFilter struct {
 	Domain     string      `json:"domain"`
 	EventTypes []string    `json:"event_types"`
-	Criteria   interface{} `json:"criteria"`
+	Criteria   interface{} `json:"criteria,omitempty"`
 }
 
 type Handler struct {
@@ -73,73 +81,177 @@ const WhUrl = webhooksUrl + "/{webhookKey}"
 
 const currentSchemaVersion = 2
 
-func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
+var domainCriteriaLookup = map[string]interface{}{
+	"artifact":                    RepoWebhookCriteria{},
+	"artifact_property":           RepoWebhookCriteria{},
+	"docker":                      RepoWebhookCriteria{},
+	"build":                       BuildWebhookCriteria{},
+	"release_bundle":              ReleaseBundleWebhookCriteria{},
+	"distribution":                ReleaseBundleWebhookCriteria{},
+	"artifactory_release_bundle":  ReleaseBundleWebhookCriteria{},
+	"release_bundle_v2":           ReleaseBundleV2WebhookCriteria{},
+	"release_bundle_v2_promotion": ReleaseBundleV2PromotionWebhookCriteria{},
+}
+
+var domainSchemaLookup = func(version int, webhookType string) map[string]map[string]*schema.Schema {
+	return map[string]map[string]*schema.Schema{
+		"artifact":                    repoWebhookSchema(webhookType, version),
+		"artifact_property":           repoWebhookSchema(webhookType, version),
+		"docker":                      repoWebhookSchema(webhookType, version),
+		"build":                       buildWebhookSchema(webhookType, version),
+		"release_bundle":              releaseBundleWebhookSchema(webhookType, version),
+		"distribution":                releaseBundleWebhookSchema(webhookType, version),
+		"artifactory_release_bundle":  releaseBundleWebhookSchema(webhookType, version),
+		"user":                        noCriteriaWebhookSchema(webhookType, version),
+		"release_bundle_v2":           releaseBundleV2WebhookSchema(webhookType, version),
+		"release_bundle_v2_promotion": releaseBundleV2PromotionWebhookSchema(webhookType, version),
+		"artifact_lifecycle":          noCriteriaWebhookSchema(webhookType, version),
+	}
+}
+
+var domainPackLookup = map[string]func(map[string]interface{}) map[string]interface{}{
+	"artifact":                    packRepoCriteria,
+	"artifact_property":           packRepoCriteria,
+	"docker":                      packRepoCriteria,
+	"build":                       packBuildCriteria,
+	"release_bundle":              packReleaseBundleCriteria,
+	"distribution":                packReleaseBundleCriteria,
+	"artifactory_release_bundle":  packReleaseBundleCriteria,
+	"release_bundle_v2":           packReleaseBundleV2Criteria,
+	"release_bundle_v2_promotion": packReleaseBundleV2PromotionCriteria,
+}
 
-	var domainCriteriaLookup = map[string]interface{}{
-		"artifact":                   RepoWebhookCriteria{},
-		"artifact_property":          RepoWebhookCriteria{},
-		"docker":                     RepoWebhookCriteria{},
-		"build":                      BuildWebhookCriteria{},
-		"release_bundle":             ReleaseBundleWebhookCriteria{},
-		"distribution":               ReleaseBundleWebhookCriteria{},
-		"artifactory_release_bundle": ReleaseBundleWebhookCriteria{},
+var domainUnpackLookup = map[string]func(map[string]interface{}, BaseWebhookCriteria) interface{}{
+	"artifact":                    unpackRepoCriteria,
+	"artifact_property":           unpackRepoCriteria,
+	"docker":                      unpackRepoCriteria,
+	"build":                       unpackBuildCriteria,
+	"release_bundle":              unpackReleaseBundleCriteria,
+	"distribution":                unpackReleaseBundleCriteria,
+	"artifactory_release_bundle":  unpackReleaseBundleCriteria,
+	"release_bundle_v2":           unpackReleaseBundleV2Criteria,
+	"release_bundle_v2_promotion": unpackReleaseBundleV2PromotionCriteria,
+}
+
+var domainCriteriaValidationLookup = map[string]func(context.Context, map[string]interface{}) error{
+	"artifact":                   repoCriteriaValidation,
+	"artifact_property":          repoCriteriaValidation,
+	"docker":                     repoCriteriaValidation,
+	"build":                      buildCriteriaValidation,
+	"release_bundle":             releaseBundleCriteriaValidation,
+	"distribution":               releaseBundleCriteriaValidation,
+	"artifactory_release_bundle": releaseBundleCriteriaValidation,
+	"release_bundle_v2":          releaseBundleV2CriteriaValidation,
+}
+
+func unpackCriteria(d *util.ResourceData, webhookType string) interface{} {
+	var webhookCriteria interface{}
+
+	unpack, ok := domainUnpackLookup[webhookType]
+	if !ok {
+		return webhookCriteria
 	}
 
-	var domainSchemaLookup = func(version int) map[string]map[string]*schema.Schema {
-		return map[string]map[string]*schema.Schema{
-			"artifact":                   repoWebhookSchema(webhookType, version),
-			"artifact_property":          repoWebhookSchema(webhookType, version),
-			"docker":                     repoWebhookSchema(webhookType, version),
-			"build":                      buildWebhookSchema(webhookType, version),
-			"release_bundle":             releaseBundleWebhookSchema(webhookType, version),
-			"distribution":               releaseBundleWebhookSchema(webhookType, version),
-			"artifactory_release_bundle": releaseBundleWebhookSchema(webhookType, version),
+	if v, ok := d.GetOk("criteria"); ok {
+		criteria := v.(*schema.Set).List()
+		if len(criteria) == 1 {
+			id := criteria[0].(map[string]interface{})
+
+			baseCriteria := BaseWebhookCriteria{}
+			if _, ok := id["include_patterns"]; ok {
+				baseCriteria.IncludePatterns = util.CastToStringArr(id["include_patterns"].(*schema.Set).List())
+				baseCriteria.ExcludePatterns = util.CastToStringArr(id["exclude_patterns"].(*schema.Set).List())
+			}
+
+			webhookCriteria = unpack(id, baseCriteria)
 		}
 	}
 
-	var domainPackLookup = map[string]func(map[string]interface{}) map[string]interface{}{
-		"artifact":                   packRepoCriteria,
-		"artifact_property":          packRepoCriteria,
-		"docker":                     packRepoCriteria,
-		"build":                      packBuildCriteria,
-		"release_bundle":             packReleaseBundleCriteria,
-		"distribution":               packReleaseBundleCriteria,
-		"artifactory_release_bundle": packReleaseBundleCriteria,
+	return webhookCriteria
+}
+
+func packCriteria(d *schema.ResourceData, webhookType string, criteria map[string]interface{}) []error {
+	setValue := util.MkLens(d)
+
+	criteriaSchema, ok := domainSchemaLookup(currentSchemaVersion, webhookType)[webhookType]["criteria"]
+	if !ok {
+		return nil
 	}
 
-	var domainUnpackLookup = map[string]func(map[string]interface{}, BaseWebhookCriteria) interface{}{
-		"artifact":                   unpackRepoCriteria,
-		"artifact_property":          unpackRepoCriteria,
-		"docker":                     unpackRepoCriteria,
-		"build":                      unpackBuildCriteria,
-		"release_bundle":             unpackReleaseBundleCriteria,
-		"distribution":               unpackReleaseBundleCriteria,
-		"artifactory_release_bundle": unpackReleaseBundleCriteria,
+	resource := criteriaSchema.Elem.(*schema.Resource)
+	packedCriteria := domainPackLookup[webhookType](criteria)
+
+	if _, ok := resource.Schema["include_patterns"]; ok {
+		packedCriteria["include_patterns"] = schema.NewSet(schema.HashString, criteria["includePatterns"].([]interface{}))
+		packedCriteria["exclude_patterns"] = schema.NewSet(schema.HashString, criteria["excludePatterns"].([]interface{}))
 	}
 
-	var unpackWebhook = func(data *schema.ResourceData) (BaseParams, error) {
-		d := &util.ResourceData{ResourceData: data}
+	return setValue("criteria", schema.NewSet(schema.HashResource(resource), []interface{}{packedCriteria}))
+}
 
-		var unpackCriteria = func(d *util.ResourceData, webhookType string) interface{} {
-			var webhookCriteria interface{}
+func retryOnProxyError(response *resty.Response, _r error) bool {
+	var proxyNotFoundRegex = regexp.MustCompile("proxy with key '.*' not found")
 
-			if v, ok := d.GetOk("criteria"); ok {
-				criteria := v.(*schema.Set).List()
-				if len(criteria) == 1 {
-					id := criteria[0].(map[string]interface{})
+	return proxyNotFoundRegex.MatchString(string(response.Body()[:]))
+}
 
-					baseCriteria := BaseWebhookCriteria{
-						IncludePatterns: util.CastToStringArr(id["include_patterns"].(*schema.Set).List()),
-						ExcludePatterns: util.CastToStringArr(id["exclude_patterns"].(*schema.Set).List()),
-					}
+func deleteWebhook(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
+	tflog.Debug(ctx, "deleteWebhook")
 
-					webhookCriteria = domainUnpackLookup[webhookType](id, baseCriteria)
-				}
+	resp, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("webhookKey", data.Id()).
+		Delete(WhUrl)
+
+	if err != nil && resp.StatusCode() == http.StatusNotFound {
+		data.SetId("")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func eventTypesDiff(webhookType string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+		tflog.Debug(ctx, "eventTypesDiff")
+
+		eventTypes := diff.Get("event_types").(*schema.Set).List()
+		if len(eventTypes) == 0 {
+			return nil
+		}
+
+		eventTypesSupported := DomainEventTypesSupported[webhookType]
+		for _, eventType := range eventTypes {
+			if !slices.Contains(eventTypesSupported, eventType.(string)) {
+				return fmt.Errorf("event_type %s not supported for domain %s", eventType, webhookType)
 			}
+		}
+		return nil
+	}
+}
+
+func criteriaDiff(webhookType string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+		tflog.Debug(ctx, "criteriaDiff")
+
+		validate, ok := domainCriteriaValidationLookup[webhookType]
+		if !ok {
+			return nil
+		}
 
-			return webhookCriteria
+		criteria := diff.Get("criteria").(*schema.Set).List()
+		if len(criteria) == 0 {
+			return nil
 		}
 
+		return validate(ctx, criteria[0].(map[string]interface{}))
+	}
+}
+
+func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
+
+	var unpackWebhook = func(data *schema.ResourceData) (BaseParams, error) {
+		d := &util.ResourceData{ResourceData: data}
+
 		var unpackCustomHttpHeaders = func(customHeaders map[string]interface{}) []CustomHttpHeader {
 			var headers []CustomHttpHeader
 
@@ -195,18 +307,6 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 		return webhook, nil
 	}
 
-	var packCriteria = func(d *schema.ResourceData, criteria map[string]interface{}) []error {
-		setValue := util.MkLens(d)
-
-		resource := domainSchemaLookup(currentSchemaVersion)[webhookType]["criteria"].Elem.(*schema.Resource)
-		packedCriteria := domainPackLookup[webhookType](criteria)
-
-		packedCriteria["include_patterns"] = schema.NewSet(schema.HashString, criteria["includePatterns"].([]interface{}))
-		packedCriteria["exclude_patterns"] = schema.NewSet(schema.HashString, criteria["excludePatterns"].([]interface{}))
-
-		return setValue("criteria", schema.NewSet(schema.HashResource(resource), []interface{}{packedCriteria}))
-	}
-
 	var packCustomHeaders = func(customHeaders []CustomHttpHeader) map[string]interface{} {
 		headers := make(map[string]interface{})
 		for _, customHeader := range customHeaders {
@@ -219,7 +319,7 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 	var packHandlers = func(d *schema.ResourceData, handlers []Handler) []error {
 		setValue := util.MkLens(d)
 
-		resource := domainSchemaLookup(currentSchemaVersion)[webhookType]["handler"].Elem.(*schema.Resource)
+		resource := domainSchemaLookup(currentSchemaVersion, webhookType)[webhookType]["handler"].Elem.(*schema.Resource)
 
 		packedHandlers := make([]interface{}, len(handlers))
 
@@ -245,7 +345,8 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 		errors = append(errors, setValue("description", webhook.Description)...)
 		errors = append(errors, setValue("enabled", webhook.Enabled)...)
 		errors = append(errors, setValue("event_types", webhook.EventFilter.EventTypes)...)
-		errors = append(errors, packCriteria(d, webhook.EventFilter.Criteria.(map[string]interface{}))...)
+		criteria, _ := webhook.EventFilter.Criteria.(map[string]interface{})
+		errors = append(errors, packCriteria(d, webhookType, criteria)...)
 		errors = append(errors, packHandlers(d, webhook.Handlers)...)
 
 		if len(errors) > 0 {
@@ -274,12 +375,6 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 		return packWebhook(data, webhook)
 	}
 
-	var retryOnProxyError = func(response *resty.Response, _r error) bool {
-		var proxyNotFoundRegex = regexp.MustCompile("proxy with key '.*' not found")
-
-		return proxyNotFoundRegex.MatchString(string(response.Body()[:]))
-	}
-
 	var createWebhook = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
 		tflog.Debug(ctx, "createWebhook")
 
@@ -298,7 +393,11 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 
 		data.SetId(webhook.Id())
 
-		return readWebhook(ctx, data, m)
+		if diags := readWebhook(ctx, data, m); diags.HasError() {
+			return diags
+		}
+
+		return testOnApply(ctx, data, m)
 	}
 
 	var updateWebhook = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -320,66 +419,17 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 
 		data.SetId(webhook.Id())
 
-		return readWebhook(ctx, data, m)
-	}
-
-	var deleteWebhook = func(ctx context.Context, data *schema.ResourceData, m interface{}) diag.Diagnostics {
-		tflog.Debug(ctx, "deleteWebhook")
-
-		resp, err := m.(util.ProvderMetadata).Client.R().
-			SetPathParam("webhookKey", data.Id()).
-			Delete(WhUrl)
-
-		if err != nil && resp.StatusCode() == http.StatusNotFound {
-			data.SetId("")
-			return diag.FromErr(err)
-		}
-
-		return nil
-	}
-
-	var domainCriteriaValidationLookup = map[string]func(context.Context, map[string]interface{}) error{
-		"artifact":                   repoCriteriaValidation,
-		"artifact_property":          repoCriteriaValidation,
-		"docker":                     repoCriteriaValidation,
-		"build":                      buildCriteriaValidation,
-		"release_bundle":             releaseBundleCriteriaValidation,
-		"distribution":               releaseBundleCriteriaValidation,
-		"artifactory_release_bundle": releaseBundleCriteriaValidation,
-	}
-
-	var eventTypesDiff = func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
-		tflog.Debug(ctx, "eventTypesDiff")
-
-		eventTypes := diff.Get("event_types").(*schema.Set).List()
-		if len(eventTypes) == 0 {
-			return nil
-		}
-
-		eventTypesSupported := DomainEventTypesSupported[webhookType]
-		for _, eventType := range eventTypes {
-			if !slices.Contains(eventTypesSupported, eventType.(string)) {
-				return fmt.Errorf("event_type %s not supported for domain %s", eventType, webhookType)
-			}
-		}
-		return nil
-	}
-
-	var criteriaDiff = func(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
-		tflog.Debug(ctx, "criteriaDiff")
-
-		criteria := diff.Get("criteria").(*schema.Set).List()
-		if len(criteria) == 0 {
-			return nil
+		if diags := readWebhook(ctx, data, m); diags.HasError() {
+			return diags
 		}
 
-		return domainCriteriaValidationLookup[webhookType](ctx, criteria[0].(map[string]interface{}))
+		return testOnApply(ctx, data, m)
 	}
 
 	// Previous version of the schema
 	// see example in https://www.terraform.io/plugin/sdkv2/resources/state-migration#terraform-v0-12-sdk-state-migrations
 	resourceSchemaV1 := &schema.Resource{
-		Schema: domainSchemaLookup(1)[webhookType],
+		Schema: domainSchemaLookup(1, webhookType)[webhookType],
 	}
 
 	return &schema.Resource{
@@ -393,7 +443,7 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		Schema: domainSchemaLookup(currentSchemaVersion)[webhookType],
+		Schema: domainSchemaLookup(currentSchemaVersion, webhookType)[webhookType],
 		StateUpgraders: []schema.StateUpgrader{
 			{
 				Type:    resourceSchemaV1.CoreConfigSchema().ImpliedType(),
@@ -403,8 +453,8 @@ func ResourceArtifactoryWebhook(webhookType string) *schema.Resource {
 		},
 
 		CustomizeDiff: customdiff.All(
-			eventTypesDiff,
-			criteriaDiff,
+			eventTypesDiff(webhookType),
+			criteriaDiff(webhookType),
 		),
 		Description: "Provides an Artifactory webhook resource",
 	}
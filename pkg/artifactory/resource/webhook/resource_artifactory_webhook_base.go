@@ -36,6 +36,11 @@ func getBaseSchemaByVersion(webhookType string, version int) map[string]*schema.
 	return baseWebhookBaseSchemaV2(webhookType)
 }
 
+// noCriteriaWebhookSchema is the schema of the domains whose events can't be filtered, e.g. user
+var noCriteriaWebhookSchema = func(webhookType string, version int) map[string]*schema.Schema {
+	return getBaseSchemaByVersion(webhookType, version)
+}
+
 func baseWebhookBaseSchemaV1(webhookType string) map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"key": {
@@ -134,6 +139,12 @@ func baseWebhookBaseSchemaV2(webhookType string) map[string]*schema.Schema {
 			Description: fmt.Sprintf("List of Events in Artifactory, Distribution, Release Bundle that function as the event trigger for the Webhook.\n"+
 				"Allow values: %v", strings.Trim(strings.Join(DomainEventTypesSupported[webhookType], ", "), "[]")),
 		},
+		"test_on_apply": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Send a test event to the handlers after the webhook is created or updated. The apply fails with " +
+				"the status code of the handler when the test event isn't delivered. Default to 'false'",
+		},
 		"handler": {
 			Type:     schema.TypeSet,
 			Required: true,
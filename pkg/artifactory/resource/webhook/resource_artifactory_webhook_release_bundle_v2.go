@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+type ReleaseBundleV2WebhookCriteria struct {
+	BaseWebhookCriteria
+	AnyReleaseBundle       bool     `json:"anyReleaseBundle"`
+	SelectedReleaseBundles []string `json:"selectedReleaseBundles"`
+}
+
+var releaseBundleV2WebhookSchema = func(webhookType string, version int) map[string]*schema.Schema {
+	return util.MergeMaps(getBaseSchemaByVersion(webhookType, version), map[string]*schema.Schema{
+		"criteria": {
+			Type:     schema.TypeSet,
+			Required: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: util.MergeMaps(baseCriteriaSchema, map[string]*schema.Schema{
+					"any_release_bundle": {
+						Type:        schema.TypeBool,
+						Required:    true,
+						Description: "Trigger on any release bundles v2",
+					},
+					"selected_release_bundles": {
+						Type:        schema.TypeSet,
+						Required:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "Trigger on this list of release bundle v2 names",
+					},
+				}),
+			},
+			Description: "Specifies where the webhook will be applied, on which release bundles v2.",
+		},
+	})
+}
+
+var packReleaseBundleV2Criteria = func(artifactoryCriteria map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"any_release_bundle":       artifactoryCriteria["anyReleaseBundle"].(bool),
+		"selected_release_bundles": schema.NewSet(schema.HashString, artifactoryCriteria["selectedReleaseBundles"].([]interface{})),
+	}
+}
+
+var unpackReleaseBundleV2Criteria = func(terraformCriteria map[string]interface{}, baseCriteria BaseWebhookCriteria) interface{} {
+	return ReleaseBundleV2WebhookCriteria{
+		AnyReleaseBundle:       terraformCriteria["any_release_bundle"].(bool),
+		SelectedReleaseBundles: util.CastToStringArr(terraformCriteria["selected_release_bundles"].(*schema.Set).List()),
+		BaseWebhookCriteria:    baseCriteria,
+	}
+}
+
+var releaseBundleV2CriteriaValidation = func(ctx context.Context, criteria map[string]interface{}) error {
+	tflog.Debug(ctx, "releaseBundleV2CriteriaValidation")
+
+	anyReleaseBundle := criteria["any_release_bundle"].(bool)
+	selectedReleaseBundles := criteria["selected_release_bundles"].(*schema.Set).List()
+
+	if anyReleaseBundle == false && len(selectedReleaseBundles) == 0 {
+		return fmt.Errorf("selected_release_bundles cannot be empty when any_release_bundle is false")
+	}
+
+	return nil
+}
+
+// ReleaseBundleV2PromotionWebhookCriteria has no include and exclude patterns, the promotions being filtered by
+// environment only
+type ReleaseBundleV2PromotionWebhookCriteria struct {
+	SelectedEnvironments []string `json:"selectedEnvironments"`
+}
+
+var releaseBundleV2PromotionWebhookSchema = func(webhookType string, version int) map[string]*schema.Schema {
+	return util.MergeMaps(getBaseSchemaByVersion(webhookType, version), map[string]*schema.Schema{
+		"criteria": {
+			Type:     schema.TypeSet,
+			Required: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"selected_environments": {
+						Type:        schema.TypeSet,
+						Required:    true,
+						MinItems:    1,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "Trigger on the promotions to this list of environments, e.g. `PROD`",
+					},
+				},
+			},
+			Description: "Specifies where the webhook will be applied, on which environments.",
+		},
+	})
+}
+
+var packReleaseBundleV2PromotionCriteria = func(artifactoryCriteria map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"selected_environments": schema.NewSet(schema.HashString, artifactoryCriteria["selectedEnvironments"].([]interface{})),
+	}
+}
+
+var unpackReleaseBundleV2PromotionCriteria = func(terraformCriteria map[string]interface{}, _ BaseWebhookCriteria) interface{} {
+	return ReleaseBundleV2PromotionWebhookCriteria{
+		SelectedEnvironments: util.CastToStringArr(terraformCriteria["selected_environments"].(*schema.Set).List()),
+	}
+}
@@ -2,7 +2,11 @@ package webhook_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"regexp"
 	"strings"
@@ -10,6 +14,7 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/acctest"
 	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/webhook"
 	"github.com/jfrog/terraform-provider-shared/client"
@@ -34,6 +39,7 @@ var domainValidationErrorMessageLookup = map[string]string{
 	"release_bundle":             "registered_release_bundle_names cannot be empty when any_release_bundle is false",
 	"distribution":               "registered_release_bundle_names cannot be empty when any_release_bundle is false",
 	"artifactory_release_bundle": "registered_release_bundle_names cannot be empty when any_release_bundle is false",
+	"release_bundle_v2":          "selected_release_bundles cannot be empty when any_release_bundle is false",
 }
 
 var repoTemplate = `
@@ -82,8 +88,27 @@ var releaseBundleTemplate = `
 	}
 `
 
+var releaseBundleV2Template = `
+	resource "artifactory_{{ .webhookType }}_webhook" "{{ .webhookName }}" {
+		key         = "{{ .webhookName }}"
+		description = "test description"
+		event_types = [{{ range $index, $eventType := .eventTypes}}{{if $index}},{{end}}"{{$eventType}}"{{end}}]
+		criteria {
+			any_release_bundle = false
+			selected_release_bundles = []
+		}
+		handler {
+			url = "https://tempurl.org"
+		}
+	}
+`
+
 func TestAccWebhookCriteriaValidation(t *testing.T) {
 	for _, webhookType := range webhook.TypesSupported {
+		// the events of the other domains, e.g. user, can't be filtered
+		if _, ok := domainValidationErrorMessageLookup[webhookType]; !ok {
+			continue
+		}
 		title := fmt.Sprintf(
 			"TestWebhook%sCriteriaValidation",
 			cases.Title(language.AmericanEnglish).String(strings.ToLower(webhookType)),
@@ -107,6 +132,8 @@ func webhookCriteriaValidationTestCase(webhookType string, t *testing.T) (*testi
 		template = buildTemplate
 	case "release_bundle", "distribution", "artifactory_release_bundle":
 		template = releaseBundleTemplate
+	case "release_bundle_v2":
+		template = releaseBundleV2Template
 	}
 
 	params := map[string]interface{}{
@@ -367,6 +394,50 @@ func webhookTestCase(webhookType string, t *testing.T) (*testing.T, resource.Tes
 	}
 }
 
+func TestAccWebhookUser(t *testing.T) {
+	_, fqrn, name := test.MkNames("webhook-", "artifactory_user_webhook")
+
+	params := map[string]interface{}{
+		"webhookName": name,
+		"eventTypes":  webhook.DomainEventTypesSupported["user"],
+	}
+	webhookConfig := util.ExecuteTemplate("TestAccWebhookUser", `
+		resource "artifactory_user_webhook" "{{ .webhookName }}" {
+			key         = "{{ .webhookName }}"
+			description = "test description"
+			event_types = [{{ range $index, $eventType := .eventTypes}}{{if $index}},{{end}}"{{$eventType}}"{{end}}]
+			handler {
+				url    = "https://tempurl.org"
+				secret = "fake-secret"
+			}
+		}
+	`, params)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { acctest.PreCheck(t) },
+		ProviderFactories: acctest.ProviderFactories,
+		CheckDestroy:      acctest.VerifyDeleted(fqrn, testCheckWebhook),
+
+		Steps: []resource.TestStep{
+			{
+				Config: webhookConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "key", name),
+					resource.TestCheckResourceAttr(fqrn, "event_types.#", fmt.Sprintf("%d", len(webhook.DomainEventTypesSupported["user"]))),
+					resource.TestCheckResourceAttr(fqrn, "handler.#", "1"),
+					resource.TestCheckNoResourceAttr(fqrn, "criteria.#"),
+				),
+			},
+			{
+				ResourceName:      fqrn,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateCheck:  validator.CheckImportState(name, "key"),
+			},
+		},
+	})
+}
+
 func testCheckWebhook(id string, request *resty.Request) (*resty.Response, error) {
 	return request.
 		SetPathParam("webhookKey", id).
@@ -488,3 +559,86 @@ func TestWebhookResourceStateUpgradeV1(t *testing.T) {
 		t.Fatalf("expected: %v\n\ngot: %v", v2Data, actual)
 	}
 }
+
+// webhookStandIn answers the subscriptions API the way Artifactory does, and delivers the test events with the given
+// status code
+func webhookStandIn(t *testing.T, handlerStatusCode int) util.ProvderMetadata {
+	var subscription []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/test"):
+			delivery := webhook.Delivery{
+				EventType:  "deployed",
+				Url:        "https://tempurl.org",
+				Success:    handlerStatusCode < 300,
+				StatusCode: handlerStatusCode,
+			}
+			if !delivery.Success {
+				delivery.Error = http.StatusText(handlerStatusCode)
+			}
+			_ = json.NewEncoder(w).Encode(webhook.Deliveries{Deliveries: []webhook.Delivery{delivery}})
+		case r.Method == http.MethodPost || r.Method == http.MethodPut:
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			subscription = body
+		case r.Method == http.MethodGet:
+			_, _ = w.Write(subscription)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	restyClient, err := client.Build(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return util.ProvderMetadata{Client: restyClient}
+}
+
+func TestWebhookTestOnApply(t *testing.T) {
+	artifactWebhook := webhook.ResourceArtifactoryWebhook("artifact")
+
+	for _, statusCode := range []int{http.StatusOK, http.StatusBadGateway} {
+		t.Run(http.StatusText(statusCode), func(t *testing.T) {
+			m := webhookStandIn(t, statusCode)
+
+			d := schema.TestResourceDataRaw(t, artifactWebhook.Schema, map[string]interface{}{
+				"key":           "test-on-apply",
+				"event_types":   []interface{}{"deployed"},
+				"test_on_apply": true,
+				"criteria": []interface{}{
+					map[string]interface{}{
+						"any_local":        true,
+						"any_remote":       false,
+						"repo_keys":        []interface{}{"generic-local"},
+						"include_patterns": []interface{}{"foo/**"},
+						"exclude_patterns": []interface{}{"bar/**"},
+					},
+				},
+				"handler": []interface{}{
+					map[string]interface{}{"url": "https://tempurl.org"},
+				},
+			})
+
+			diags := artifactWebhook.CreateContext(context.Background(), d, m)
+			if statusCode == http.StatusOK {
+				if diags.HasError() {
+					t.Fatalf("expected the test event to be delivered, got: %v", diags)
+				}
+				return
+			}
+
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, "failed with status code 502") {
+				t.Errorf("expected the apply to fail with the status code of the handler, got: %v", diags)
+			}
+			if d.Id() != "test-on-apply" {
+				t.Errorf("expected the webhook to be kept in the state, got ID %q", d.Id())
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// WhDeliveriesUrl isn't part of the documented REST API, like the test endpoint
+const WhDeliveriesUrl = WhUrl + "/deliveries"
+
+// Delivery is an attempt to send an event to a handler of the webhook
+type Delivery struct {
+	Id         string `json:"id"`
+	EventType  string `json:"event_type"`
+	Url        string `json:"url"`
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error"`
+	Timestamp  string `json:"timestamp"`
+}
+
+type Deliveries struct {
+	Deliveries []Delivery `json:"deliveries"`
+}
+
+// ListDeliveries returns the most recent deliveries of the webhook, first the most recent
+func ListDeliveries(webhookKey string, limit int, m interface{}) ([]Delivery, error) {
+	deliveries := Deliveries{}
+
+	_, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("webhookKey", webhookKey).
+		SetQueryParam("limit", fmt.Sprintf("%d", limit)).
+		SetResult(&deliveries).
+		Get(WhDeliveriesUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries.Deliveries, nil
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+const WhTestUrl = WhUrl + "/test"
+
+// testOnApply sends a test event to the handlers of the webhook when `test_on_apply` is set, and fails with the
+// status code returned by the first handler which didn't accept it. The test endpoint isn't part of the documented
+// REST API: when it fails itself, its own status code is reported.
+func testOnApply(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.Get("test_on_apply").(bool) {
+		return nil
+	}
+
+	tflog.Debug(ctx, "testOnApply")
+
+	result := Deliveries{}
+	resp, err := m.(util.ProvderMetadata).Client.R().
+		SetPathParam("webhookKey", d.Id()).
+		SetResult(&result).
+		Post(WhTestUrl)
+	if err != nil {
+		if resp != nil && resp.StatusCode() != 0 {
+			return diag.Errorf("test of webhook %s failed with status code %d: %s", d.Id(), resp.StatusCode(), resp.String())
+		}
+		return diag.Errorf("failed to test webhook %s: %s", d.Id(), err)
+	}
+
+	for _, delivery := range result.Deliveries {
+		if !delivery.Success {
+			return diag.Errorf("test delivery of webhook %s to %s failed with status code %d: %s",
+				d.Id(), delivery.Url, delivery.StatusCode, delivery.Error)
+		}
+	}
+
+	return nil
+}
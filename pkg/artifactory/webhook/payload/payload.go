@@ -0,0 +1,159 @@
+// Package payload provides the events sent by the Artifactory webhooks to their handlers, and the verification of
+// their signature, for the services receiving them.
+package payload
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Event is the body of the request sent to the handlers of a webhook. Data depends on the domain of the webhook.
+type Event[D any] struct {
+	Domain          string `json:"domain"`
+	EventType       string `json:"event_type"`
+	Data            D      `json:"data"`
+	SubscriptionKey string `json:"subscription_key"`
+	JpdOrigin       string `json:"jpd_origin"`
+	Source          string `json:"source"`
+}
+
+type ArtifactData struct {
+	RepoKey string `json:"repo_key"`
+	Path    string `json:"path"`
+	Name    string `json:"name"`
+	Sha256  string `json:"sha256"`
+	Size    int64  `json:"size"`
+	// SourceRepoPath and TargetRepoPath are only set for the moved and copied events
+	SourceRepoPath string `json:"source_repo_path,omitempty"`
+	TargetRepoPath string `json:"target_repo_path,omitempty"`
+}
+
+type ArtifactPropertyData struct {
+	RepoKey        string   `json:"repo_key"`
+	Path           string   `json:"path"`
+	Name           string   `json:"name"`
+	Sha256         string   `json:"sha256"`
+	Size           int64    `json:"size"`
+	PropertyKey    string   `json:"property_key"`
+	PropertyValues []string `json:"property_values"`
+}
+
+type DockerPlatform struct {
+	Architecture string `json:"architecture"`
+	Os           string `json:"os"`
+}
+
+type DockerData struct {
+	RepoKey   string           `json:"repo_key"`
+	Path      string           `json:"path"`
+	Name      string           `json:"name"`
+	Sha256    string           `json:"sha256"`
+	Size      int64            `json:"size"`
+	ImageName string           `json:"image_name"`
+	Tag       string           `json:"tag"`
+	Platforms []DockerPlatform `json:"platforms"`
+}
+
+type BuildData struct {
+	BuildName    string `json:"build_name"`
+	BuildNumber  string `json:"build_number"`
+	BuildStarted string `json:"build_started"`
+}
+
+type ReleaseBundleData struct {
+	ReleaseBundleName    string `json:"release_bundle_name"`
+	ReleaseBundleVersion string `json:"release_bundle_version"`
+	ReleaseBundleSize    int64  `json:"release_bundle_size"`
+}
+
+type DistributionSite struct {
+	ServiceId string `json:"service_id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+}
+
+type DistributionData struct {
+	Id                   int64              `json:"id"`
+	ReleaseBundleName    string             `json:"release_bundle_name"`
+	ReleaseBundleVersion string             `json:"release_bundle_version"`
+	Status               string             `json:"status"`
+	Type                 string             `json:"type"`
+	Sites                []DistributionSite `json:"sites"`
+}
+
+type ArtifactoryReleaseBundleData struct {
+	ReleaseBundleName    string `json:"release_bundle_name"`
+	ReleaseBundleVersion string `json:"release_bundle_version"`
+}
+
+type UserData struct {
+	Username string `json:"username"`
+	Realm    string `json:"realm"`
+}
+
+type ReleaseBundleV2Data struct {
+	ReleaseBundleName    string `json:"release_bundle_name"`
+	ReleaseBundleVersion string `json:"release_bundle_version"`
+	ProjectKey           string `json:"project_key"`
+}
+
+type ReleaseBundleV2PromotionData struct {
+	ReleaseBundleName    string `json:"release_bundle_name"`
+	ReleaseBundleVersion string `json:"release_bundle_version"`
+	ProjectKey           string `json:"project_key"`
+	Environment          string `json:"environment"`
+}
+
+type ArtifactLifecycleData struct {
+	RepoKey string `json:"repo_key"`
+	Path    string `json:"path"`
+	Name    string `json:"name"`
+}
+
+type ArtifactEvent = Event[ArtifactData]
+type ArtifactPropertyEvent = Event[ArtifactPropertyData]
+type DockerEvent = Event[DockerData]
+type BuildEvent = Event[BuildData]
+type ReleaseBundleEvent = Event[ReleaseBundleData]
+type DistributionEvent = Event[DistributionData]
+type ArtifactoryReleaseBundleEvent = Event[ArtifactoryReleaseBundleData]
+type UserEvent = Event[UserData]
+type ReleaseBundleV2Event = Event[ReleaseBundleV2Data]
+type ReleaseBundleV2PromotionEvent = Event[ReleaseBundleV2PromotionData]
+type ArtifactLifecycleEvent = Event[ArtifactLifecycleData]
+
+// domainEvents returns a new event of each domain supported by the webhook resources
+var domainEvents = map[string]func() interface{}{
+	"artifact":                    func() interface{} { return &ArtifactEvent{} },
+	"artifact_property":           func() interface{} { return &ArtifactPropertyEvent{} },
+	"docker":                      func() interface{} { return &DockerEvent{} },
+	"build":                       func() interface{} { return &BuildEvent{} },
+	"release_bundle":              func() interface{} { return &ReleaseBundleEvent{} },
+	"distribution":                func() interface{} { return &DistributionEvent{} },
+	"artifactory_release_bundle":  func() interface{} { return &ArtifactoryReleaseBundleEvent{} },
+	"user":                        func() interface{} { return &UserEvent{} },
+	"release_bundle_v2":           func() interface{} { return &ReleaseBundleV2Event{} },
+	"release_bundle_v2_promotion": func() interface{} { return &ReleaseBundleV2PromotionEvent{} },
+	"artifact_lifecycle":          func() interface{} { return &ArtifactLifecycleEvent{} },
+}
+
+// Parse decodes the body of a request sent by a webhook into the event of its domain, e.g. *ArtifactEvent for the
+// artifact domain. The body should be verified with VerifySignature first.
+func Parse(body []byte) (interface{}, error) {
+	header := Event[json.RawMessage]{}
+	if err := json.Unmarshal(body, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode event: %w", err)
+	}
+
+	newEvent, ok := domainEvents[header.Domain]
+	if !ok {
+		return nil, fmt.Errorf("domain %q not supported", header.Domain)
+	}
+
+	event := newEvent()
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", header.Domain, err)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,206 @@
+package payload_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/resource/webhook"
+	"github.com/jfrog/terraform-provider-artifactory/v7/pkg/artifactory/webhook/payload"
+)
+
+// the signatures of the fixtures are regenerated with `go test ./pkg/artifactory/webhook/payload -update`
+var update = flag.Bool("update", false, "update the golden signatures of the fixtures")
+
+const goldenSecret = "golden-secret"
+
+type fixture struct {
+	domain    string
+	eventType string
+	body      []byte
+	signature string
+}
+
+// fixtures reads the golden event of each event type supported by the webhook resources
+func fixtures(t *testing.T) []fixture {
+	var all []fixture
+	for domain, eventTypes := range webhook.DomainEventTypesSupported {
+		for _, eventType := range eventTypes {
+			path := filepath.Join("testdata", domain, eventType)
+			body, err := os.ReadFile(path + ".json")
+			if err != nil {
+				t.Fatalf("missing golden event for event type %s of domain %s: %s", eventType, domain, err)
+			}
+
+			if *update {
+				if err := os.WriteFile(path+".sig", []byte(payload.Sign(goldenSecret, body)+"\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			signature, err := os.ReadFile(path + ".sig")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			all = append(all, fixture{
+				domain:    domain,
+				eventType: eventType,
+				body:      body,
+				signature: strings.TrimSpace(string(signature)),
+			})
+		}
+	}
+	return all
+}
+
+func TestParse(t *testing.T) {
+	for _, f := range fixtures(t) {
+		t.Run(f.domain+"/"+f.eventType, func(t *testing.T) {
+			event, err := payload.Parse(f.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the event must hold all the fields of the golden event, and only them
+			encoded, err := json.Marshal(event)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected, actual map[string]interface{}
+			if err := json.Unmarshal(f.body, &expected); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(encoded, &actual); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(expected, actual) {
+				t.Errorf("expected: %v\n\ngot: %v", expected, actual)
+			}
+			if actual["domain"] != f.domain || actual["event_type"] != f.eventType {
+				t.Errorf("expected event %s of domain %s, got: %v", f.eventType, f.domain, actual)
+			}
+		})
+	}
+}
+
+func TestParse_typedEvent(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "docker", "pushed.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event, err := payload.Parse(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dockerEvent, ok := event.(*payload.DockerEvent)
+	if !ok {
+		t.Fatalf("expected a *payload.DockerEvent, got: %T", event)
+	}
+	if dockerEvent.Data.ImageName != "app" || dockerEvent.Data.Tag != "1.0" || len(dockerEvent.Data.Platforms) != 1 {
+		t.Errorf("unexpected docker event data: %+v", dockerEvent.Data)
+	}
+}
+
+func TestParse_unsupportedDomain(t *testing.T) {
+	_, err := payload.Parse([]byte(`{"domain": "unknown", "event_type": "created", "data": {}}`))
+	if err == nil || !strings.Contains(err.Error(), `domain "unknown" not supported`) {
+		t.Errorf("expected the domain not to be supported, got: %v", err)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	for _, f := range fixtures(t) {
+		t.Run(f.domain+"/"+f.eventType, func(t *testing.T) {
+			if signature := payload.Sign(goldenSecret, f.body); signature != f.signature {
+				t.Errorf("expected golden signature %s, got: %s", f.signature, signature)
+			}
+
+			if err := payload.VerifySignature(goldenSecret, f.signature, f.body); err != nil {
+				t.Errorf("expected the golden signature to be valid, got: %s", err)
+			}
+			if err := payload.VerifySignature(goldenSecret, "sha256="+strings.ToUpper(f.signature), f.body); err != nil {
+				t.Errorf("expected the prefixed signature to be valid, got: %s", err)
+			}
+
+			tampered := []byte(strings.Replace(string(f.body), f.eventType, "tampered", 1))
+			invalid := map[string]error{
+				"tampered body": payload.VerifySignature(goldenSecret, f.signature, tampered),
+				"wrong secret":  payload.VerifySignature("wrong-secret", f.signature, f.body),
+				"plain secret":  payload.VerifySignature(goldenSecret, goldenSecret, f.body),
+				"no signature":  payload.VerifySignature(goldenSecret, "", f.body),
+			}
+			for name, err := range invalid {
+				if !errors.Is(err, payload.ErrInvalidSignature) {
+					t.Errorf("%s: expected ErrInvalidSignature, got: %v", name, err)
+				}
+			}
+		})
+	}
+}
+
+// TestSign_rfc4231 checks the signatures against the HMAC-SHA256 test vectors of RFC 4231, as the golden signatures
+// of the fixtures are generated by Sign itself
+func TestSign_rfc4231(t *testing.T) {
+	testCases := []struct {
+		name     string
+		key      []byte
+		data     []byte
+		expected string
+	}{
+		{
+			name:     "test case 1",
+			key:      bytes.Repeat([]byte{0x0b}, 20),
+			data:     []byte("Hi There"),
+			expected: "b0344c61d8db38535ca8afceaf0bf12b881dc200c9833da726e9376c2e32cff7",
+		},
+		{
+			name:     "test case 2",
+			key:      []byte("Jefe"),
+			data:     []byte("what do ya want for nothing?"),
+			expected: "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		},
+		{
+			name:     "test case 3",
+			key:      bytes.Repeat([]byte{0xaa}, 20),
+			data:     bytes.Repeat([]byte{0xdd}, 50),
+			expected: "773ea91e36800e46854db8ebd09181a72959098b3ef8c122d9635514ced565fe",
+		},
+		{
+			name:     "test case 4",
+			key:      []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19},
+			data:     bytes.Repeat([]byte{0xcd}, 50),
+			expected: "82558a389a443c0ea4cc819899f2083a85f0faa3e578f8077a2e3ff46729665b",
+		},
+		{
+			name:     "test case 6",
+			key:      bytes.Repeat([]byte{0xaa}, 131),
+			data:     []byte("Test Using Larger Than Block-Size Key - Hash Key First"),
+			expected: "60e431591ee0b67f0d8a26aacbf5b77f8e0bc6213728c5140546040f0ee37f54",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if signature := payload.Sign(string(tc.key), tc.data); signature != tc.expected {
+				t.Errorf("expected %s, got: %s", tc.expected, signature)
+			}
+			if err := payload.VerifySignature(string(tc.key), tc.expected, tc.data); err != nil {
+				t.Errorf("expected the signature to be valid, got: %s", err)
+			}
+		})
+	}
+}
+
+func TestVerifySignature_emptySecret(t *testing.T) {
+	if err := payload.VerifySignature("", payload.Sign("", []byte("{}")), []byte("{}")); err == nil {
+		t.Error("expected an empty secret to be refused")
+	}
+}
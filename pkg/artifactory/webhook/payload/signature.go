@@ -0,0 +1,44 @@
+package payload
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// SignatureHeader is the header holding the signature of the body, or the secret of the handler itself when the
+// webhook doesn't sign its payloads
+const SignatureHeader = "X-JFrog-Event-Auth"
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Sign returns the signature of the body with the secret of the handler: the hex encoded HMAC-SHA256 of the body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature returns ErrInvalidSignature unless header, the value of SignatureHeader, is the signature of the
+// body with the secret of the handler. The signature may be prefixed with "sha256=". The signatures are compared
+// in constant time, and the body must be the raw body of the request, before any decoding.
+func VerifySignature(secret, header string, body []byte) error {
+	if secret == "" {
+		return errors.New("the secret of the handler is empty")
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(header), "sha256="))
+	if err != nil || len(signature) != sha256.Size {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}